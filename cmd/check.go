@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+)
+
+/*
+   @NAME    : check
+   @author  : 清风
+   @desc    : 检查生成代码是否过期（gen --check）及预览写入计划（gen --dry-run）
+   @time    : 2026/10/17
+*/
+
+// fileStatus 生成文件相对于磁盘上已有文件的状态
+type fileStatus string
+
+const (
+	fileCreated   fileStatus = "新增"
+	fileChanged   fileStatus = "变更"
+	fileUnchanged fileStatus = "未变"
+)
+
+// filePlan 生成文件的写入计划
+type filePlan struct {
+	Path   string
+	Size   int
+	Status fileStatus
+	Diff   string // 变更文件相对于磁盘内容的 unified diff
+}
+
+// generateInMemory 读取表结构并在内存中生成代码，不写入任何文件
+func generateInMemory(cfg *config.Config) (*memoryOutput, error) {
+	provider, err := NewSchemaProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tableInfos, err := LoadTables(context.Background(), provider, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newMemoryOutput()
+	if err := GenerateFiles(tableInfos, cfg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// planFiles 将内存中生成的文件与磁盘上的文件比较，按生成顺序返回写入计划
+func planFiles(out *memoryOutput) ([]filePlan, error) {
+	plans := make([]filePlan, 0, len(out.paths))
+	for _, path := range out.paths {
+		content := out.files[path]
+		plan := filePlan{Path: path, Size: len(content), Status: fileUnchanged}
+
+		existing, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			plan.Status = fileCreated
+		case err != nil:
+			return nil, fmt.Errorf("读取文件 %s 失败: %v", path, err)
+		case !bytes.Equal(existing, content):
+			plan.Status = fileChanged
+			plan.Diff = utils.UnifiedDiff("a/"+filepath.ToSlash(path), "b/"+filepath.ToSlash(path), existing, content)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// Check 在内存中生成代码并与磁盘上的文件比较，不写入任何文件。
+// 输出每个有差异文件的 unified diff，以及需要新增和已不再生成的文件，存在差异时返回错误。
+// 指定了表名时只生成部分表，无法判断其他文件是否多余，跳过多余文件的检查并给出提示
+func Check(cfg *config.Config) error {
+	out, err := generateInMemory(cfg)
+	if err != nil {
+		return err
+	}
+
+	plans, err := planFiles(out)
+	if err != nil {
+		return err
+	}
+
+	var changed, created []string
+	for _, plan := range plans {
+		switch plan.Status {
+		case fileChanged:
+			changed = append(changed, plan.Path)
+			fmt.Print(plan.Diff)
+		case fileCreated:
+			created = append(created, plan.Path)
+		}
+	}
+
+	var orphaned []string
+	if len(cfg.Tables) > 0 {
+		fmt.Println("指定了表名（--tables），只检查这些表生成的文件，未检查多余文件")
+	} else if orphaned, err = orphanFiles(cfg, out); err != nil {
+		return err
+	}
+
+	if len(changed) == 0 && len(created) == 0 && len(orphaned) == 0 {
+		fmt.Printf("生成代码是最新的(%d个文件)\n", len(plans))
+		return nil
+	}
+
+	fmt.Println("\n检查结果:")
+	for _, path := range changed {
+		fmt.Printf("  变更: %s\n", path)
+	}
+	for _, path := range created {
+		fmt.Printf("  新增: %s\n", path)
+	}
+	for _, path := range orphaned {
+		fmt.Printf("  多余: %s\n", path)
+	}
+	return fmt.Errorf("生成代码已过期（%d个变更，%d个新增，%d个多余），请重新执行 zero gen",
+		len(changed), len(created), len(orphaned))
+}
+
+// DryRun 在内存中生成代码，输出将要写入的文件、大小以及与磁盘内容的差异，不写入任何文件
+func DryRun(cfg *config.Config) error {
+	out, err := generateInMemory(cfg)
+	if err != nil {
+		return err
+	}
+
+	plans, err := planFiles(out)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[fileStatus]int)
+	for _, plan := range plans {
+		counts[plan.Status]++
+		fmt.Print(plan.Diff)
+	}
+
+	fmt.Println("\n生成计划:")
+	for _, plan := range plans {
+		fmt.Printf("  %s: %s (%d 字节)\n", plan.Status, plan.Path, plan.Size)
+	}
+	fmt.Printf("共%d个文件: %d个新增，%d个变更，%d个未变（dry-run，未写入任何文件）\n",
+		len(plans), counts[fileCreated], counts[fileChanged], counts[fileUnchanged])
+	return nil
+}
+
+// orphanFiles 返回输出目录中由 zero 生成、但本次不再生成的文件（如表已删除或改名）
+func orphanFiles(cfg *config.Config, out *memoryOutput) ([]string, error) {
+	seen := make(map[string]bool)
+	var orphaned []string
+	for _, dir := range []string{cfg.Output.OrmDir, cfg.Output.ModelDir, cfg.Output.QueryDir} {
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+
+		paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, fmt.Errorf("读取目录 %s 失败: %v", dir, err)
+		}
+		for _, path := range paths {
+			if _, ok := out.files[path]; ok {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("读取文件 %s 失败: %v", path, err)
+			}
+			if bytes.Contains(content, []byte(generatedHeader)) {
+				orphaned = append(orphaned, path)
+			}
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// captureStdout 执行 fn 并返回其间输出到标准输出的内容
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	defer func() {
+		os.Stdout = stdout
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+// checkConfig 返回输出到临时目录、使用 fakeTables 表结构的配置
+func checkConfig(t *testing.T) *config.Config {
+	t.Helper()
+	RegisterProvider("check", func(cfg *config.Config) (SchemaProvider, error) {
+		return &fakeProvider{tables: fakeTables()}, nil
+	})
+	t.Cleanup(func() {
+		providersMu.Lock()
+		delete(providers, "check")
+		providersMu.Unlock()
+	})
+
+	dir := t.TempDir()
+	return &config.Config{
+		Driver:     "check",
+		ModuleName: "example.com/app",
+		Style:      "snake",
+		Output: config.OutputConfig{
+			OrmDir:   filepath.Join(dir, "orm"),
+			ModelDir: filepath.Join(dir, "orm", "model"),
+			QueryDir: filepath.Join(dir, "orm", "query"),
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cfg := checkConfig(t)
+	modelDir := cfg.Output.ModelDir
+	usersModel := filepath.Join(modelDir, "users.go")
+
+	// 还没有生成过代码：所有文件都需要新增
+	var err error
+	output := captureStdout(t, func() { err = Check(cfg) })
+	if err == nil || !strings.Contains(err.Error(), "5个新增") {
+		t.Fatalf("Check 返回 %v，期望 5 个新增文件", err)
+	}
+	if _, statErr := os.Stat(cfg.Output.OrmDir); !os.IsNotExist(statErr) {
+		t.Fatalf("Check 不应写入文件")
+	}
+	if !strings.Contains(output, "新增: "+usersModel) {
+		t.Errorf("输出中缺少新增的 %s:\n%s", usersModel, output)
+	}
+
+	// 生成后是最新的
+	captureStdout(t, func() { err = Generate(context.Background(), &fakeProvider{tables: fakeTables()}, cfg) })
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	output = captureStdout(t, func() { err = Check(cfg) })
+	if err != nil || !strings.Contains(output, "生成代码是最新的") {
+		t.Fatalf("Check 返回 %v，期望代码是最新的，输出:\n%s", err, output)
+	}
+
+	// 修改过的文件输出 diff
+	content, _ := os.ReadFile(usersModel)
+	stale := strings.Replace(string(content), "type Users struct", "type Users struct // 手动修改", 1)
+	if err := os.WriteFile(usersModel, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 缺失的文件需要新增
+	if err := os.Remove(filepath.Join(modelDir, "posts.go")); err != nil {
+		t.Fatal(err)
+	}
+	// 由 zero 生成但不再生成的文件是多余的，手写的文件不算
+	if err := os.WriteFile(filepath.Join(modelDir, "comments.go"), []byte(generatedHeader+"\n\npackage model\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "helper.go"), []byte("package model\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output = captureStdout(t, func() { err = Check(cfg) })
+	if err == nil || !strings.Contains(err.Error(), "1个变更，1个新增，1个多余") {
+		t.Fatalf("Check 返回 %v，期望 1 个变更、1 个新增、1 个多余", err)
+	}
+	for _, want := range []string{
+		"-type Users struct // 手动修改",
+		"+type Users struct",
+		"变更: " + usersModel,
+		"新增: " + filepath.Join(modelDir, "posts.go"),
+		"多余: " + filepath.Join(modelDir, "comments.go"),
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("输出中缺少 %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "helper.go") {
+		t.Errorf("手写的 helper.go 不应被视为多余文件:\n%s", output)
+	}
+
+	// 指定表名时跳过多余文件的检查，并给出提示
+	cfg.Tables = []string{"users"}
+	output = captureStdout(t, func() { err = Check(cfg) })
+	if err == nil || !strings.Contains(err.Error(), "0个多余") {
+		t.Errorf("指定表名时 Check 返回 %v，期望不检查多余文件", err)
+	}
+	if !strings.Contains(output, "未检查多余文件") || strings.Contains(output, "comments.go") {
+		t.Errorf("指定表名时应提示跳过多余文件的检查，输出:\n%s", output)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	cfg := checkConfig(t)
+	usersModel := filepath.Join(cfg.Output.ModelDir, "users.go")
+
+	var err error
+	output := captureStdout(t, func() { err = DryRun(cfg) })
+	if err != nil {
+		t.Fatalf("DryRun: %v", err)
+	}
+	if _, statErr := os.Stat(cfg.Output.OrmDir); !os.IsNotExist(statErr) {
+		t.Fatalf("DryRun 不应写入文件")
+	}
+	if !strings.Contains(output, "共5个文件: 5个新增，0个变更，0个未变") || !strings.Contains(output, "新增: "+usersModel) {
+		t.Errorf("DryRun 输出不正确:\n%s", output)
+	}
+
+	captureStdout(t, func() { err = Generate(context.Background(), &fakeProvider{tables: fakeTables()}, cfg) })
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	content, _ := os.ReadFile(usersModel)
+	if err := os.WriteFile(usersModel, append(content, "// 手动修改\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output = captureStdout(t, func() { err = DryRun(cfg) })
+	if err != nil {
+		t.Fatalf("DryRun: %v", err)
+	}
+	if !strings.Contains(output, "共5个文件: 0个新增，1个变更，4个未变") || !strings.Contains(output, "-// 手动修改") {
+		t.Errorf("DryRun 输出不正确:\n%s", output)
+	}
+	after, _ := os.ReadFile(usersModel)
+	if !strings.HasSuffix(string(after), "// 手动修改\n") {
+		t.Error("DryRun 不应覆盖已有文件")
+	}
+}
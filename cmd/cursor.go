@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : cursor
+   @author  : 清风
+   @desc    : 游标分页的排序列（cursor 配置，默认使用主键）及查询方法的模板数据
+   @time    : 2026/10/17
+*/
+
+// cursor 游标分页方法的模板数据
+type cursor struct {
+	Order   string         // 排序条件，用于注释，如 created_at DESC, id DESC
+	Columns []cursorColumn // 排序列
+	Vars    []cursorField  // 解码游标使用的变量
+	Dest    string         // 解码游标的参数，如 &createdAt, &id
+	Values  string         // 编码游标的参数，如 last.CreatedAt, last.ID
+	Where   string         // 游标之后的记录的查询条件，列名也作为参数传入，以便带上表名并按数据库的方式转义
+	Args    string         // 查询参数，如 order[0].Column, createdAt
+}
+
+// cursorColumn 排序列
+type cursorColumn struct {
+	Name string // 列名
+	Desc bool   // 是否降序
+}
+
+// cursorField 游标中的一个排序列
+type cursorField struct {
+	Name string // 变量名
+	Type string // 变量类型
+}
+
+// applyCursor 确定每个表游标分页的排序列：cursor 中配置的列，未配置时使用主键，需要在 applySoftDelete、applyVersion 之后执行。
+// 排序列不能包含可为空、JSON 或类型被替换的列，否则无法比较和编码游标。
+// 排序列的组合需要唯一，配置的列不包含主键或任一唯一索引的全部列时，追加主键列作为最后的排序列，方向与最后一个配置的列相同
+func applyCursor(tables []*config.TableInfo, cfg *config.Config) error {
+	for _, table := range tables {
+		table.Cursor = nil
+		columns, configured := cursorConfig(cfg.Cursor, table.Name)
+		if !configured {
+			columns = primaryColumns(table)
+		}
+
+		var keys []config.CursorColumn
+		for _, item := range columns {
+			name, desc := parseCursorColumn(item)
+			field := findField(table, name)
+			if field == nil {
+				if configured {
+					return fmt.Errorf("表 %s 的游标分页排序列 %s 不存在", table.Name, name)
+				}
+				keys = nil
+				break
+			}
+			if !cursorComparable(field) {
+				if configured {
+					return fmt.Errorf("表 %s 的游标分页排序列 %s 不能为可为空、JSON 或替换了类型的列", table.Name, name)
+				}
+				keys = nil
+				break
+			}
+			keys = append(keys, config.CursorColumn{Field: field, Desc: desc})
+		}
+		if configured && !cursorUnique(table, keys) {
+			var err error
+			if keys, err = appendPrimaryKey(table, keys); err != nil {
+				return err
+			}
+		}
+		table.Cursor = keys
+	}
+	return nil
+}
+
+// cursorUnique 判断排序列是否包含主键或某个唯一索引的全部列
+func cursorUnique(table *config.TableInfo, keys []config.CursorColumn) bool {
+	columns := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		columns[key.Field.Name] = true
+	}
+	for _, index := range table.Indexes {
+		if !index.IsUniq || len(index.Fields) == 0 {
+			continue
+		}
+		covered := true
+		for _, column := range index.Fields {
+			if !columns[column] {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// appendPrimaryKey 在排序列后追加尚未包含的主键列，使排序列的组合唯一
+func appendPrimaryKey(table *config.TableInfo, keys []config.CursorColumn) ([]config.CursorColumn, error) {
+	primary := primaryColumns(table)
+	if len(primary) == 0 {
+		return nil, fmt.Errorf("表 %s 的游标分页排序列不唯一，且表没有主键，请在 cursor 中配置包含唯一索引全部列的排序列", table.Name)
+	}
+	desc := keys[len(keys)-1].Desc
+	for _, name := range primary {
+		field := findField(table, name)
+		if field == nil || !cursorComparable(field) {
+			return nil, fmt.Errorf("表 %s 的游标分页排序列不唯一，且主键列 %s 不能作为排序列，请在 cursor 中配置包含唯一索引全部列的排序列", table.Name, name)
+		}
+		exists := false
+		for _, key := range keys {
+			if key.Field == field {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			keys = append(keys, config.CursorColumn{Field: field, Desc: desc})
+		}
+	}
+	return keys, nil
+}
+
+// cursorConfig 返回表在 cursor 配置中的排序列（表名不区分大小写）
+func cursorConfig(list map[string][]string, table string) ([]string, bool) {
+	for name, columns := range list {
+		if strings.EqualFold(name, table) && len(columns) > 0 {
+			return columns, true
+		}
+	}
+	return nil, false
+}
+
+// primaryColumns 返回表的主键列
+func primaryColumns(table *config.TableInfo) []string {
+	for _, index := range table.Indexes {
+		if index.IsPK {
+			return index.Fields
+		}
+	}
+	return nil
+}
+
+// parseCursorColumn 解析排序列配置，如 created_at desc
+func parseCursorColumn(item string) (string, bool) {
+	parts := strings.Fields(item)
+	if len(parts) == 2 && strings.EqualFold(parts[1], "desc") {
+		return parts[0], true
+	}
+	if len(parts) == 2 && strings.EqualFold(parts[1], "asc") {
+		return parts[0], false
+	}
+	return strings.TrimSpace(item), false
+}
+
+// cursorComparable 判断字段能否作为游标分页的排序列
+func cursorComparable(field *config.FieldInfo) bool {
+	if field.IsNullable || field.JSON || field.Type != field.ValueType {
+		return false
+	}
+	return field.Enum == nil || field.Enum.SetType == ""
+}
+
+// buildCursor 生成游标分页方法的模板数据，表没有排序列时返回 nil。
+// 多个排序列时，游标之后的记录为 (a > ?) OR (a = ? AND b > ?) ...，降序的列使用 <。
+// 列名通过 order[i].Column 作为参数传入，生成带表名的列，避免 Joins 时列名有歧义
+func buildCursor(table *config.TableInfo, cfg *config.Config, modelPackage string) *cursor {
+	if len(table.Cursor) == 0 {
+		return nil
+	}
+	namer := newNamer(cfg)
+
+	c := &cursor{}
+	var order, dest, values, conds, args []string
+	for i, key := range table.Cursor {
+		field := key.Field
+		name := paramName(namer.Camel(field.Name))
+		c.Columns = append(c.Columns, cursorColumn{Name: field.Name, Desc: key.Desc})
+		c.Vars = append(c.Vars, cursorField{Name: name, Type: valueType(*field, modelPackage)})
+		dest = append(dest, "&"+name)
+		values = append(values, "last."+field.GoName)
+
+		direction, op := "", ">"
+		if key.Desc {
+			direction, op = " DESC", "<"
+		}
+		order = append(order, field.Name+direction)
+
+		var parts []string
+		for j := range table.Cursor[:i] {
+			parts = append(parts, "? = ?")
+			args = append(args, fmt.Sprintf("order[%d].Column", j), c.Vars[j].Name)
+		}
+		parts = append(parts, "? "+op+" ?")
+		args = append(args, fmt.Sprintf("order[%d].Column", i), name)
+		conds = append(conds, strings.Join(parts, " AND "))
+	}
+
+	c.Order = strings.Join(order, ", ")
+	c.Dest = strings.Join(dest, ", ")
+	c.Values = strings.Join(values, ", ")
+	c.Args = strings.Join(args, ", ")
+	if len(conds) == 1 {
+		c.Where = conds[0]
+	} else {
+		c.Where = "(" + strings.Join(conds, ") OR (") + ")"
+	}
+	return c
+}
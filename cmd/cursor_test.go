@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestApplyCursorKeepsOrderUnique(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  []string
+		want    string
+		wantErr bool
+	}{
+		{name: "默认使用主键", want: "id"},
+		{name: "非唯一列追加主键", cursor: []string{"created_at desc"}, want: "created_at DESC, id DESC"},
+		{name: "唯一索引不追加", cursor: []string{"email"}, want: "email"},
+		{name: "已包含主键不追加", cursor: []string{"created_at", "id"}, want: "created_at, id"},
+		{name: "可为空的列", cursor: []string{"nickname"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{}
+		if tt.cursor != nil {
+			cfg.Cursor = map[string][]string{"users": tt.cursor}
+		}
+		tables := fakeTables()
+		if err := applyNames(tables, cfg); err != nil {
+			t.Fatalf("%s: applyNames: %v", tt.name, err)
+		}
+		if err := applyTypes(tables, cfg); err != nil {
+			t.Fatalf("%s: applyTypes: %v", tt.name, err)
+		}
+		err := applyCursor(tables, cfg)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: applyCursor 没有返回错误", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: applyCursor: %v", tt.name, err)
+		}
+		c := buildCursor(tables[0], cfg, "model")
+		if c == nil || c.Order != tt.want {
+			t.Errorf("%s: 排序为 %+v，期望 %s", tt.name, c, tt.want)
+		}
+	}
+}
+
+func TestApplyCursorWithoutPrimaryKey(t *testing.T) {
+	tables := []*config.TableInfo{{
+		Name:   "logs",
+		Fields: []config.FieldInfo{{Name: "created_at", Type: "time.Time"}},
+	}}
+	cfg := &config.Config{Cursor: map[string][]string{"logs": {"created_at"}}}
+	if err := applyTypes(tables, cfg); err != nil {
+		t.Fatalf("applyTypes: %v", err)
+	}
+	if err := applyCursor(tables, cfg); err == nil {
+		t.Error("排序列不唯一且没有主键时 applyCursor 应返回错误")
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/inflection"
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/naming"
+	"github.com/tokmz/zero/utils"
+)
+
+/*
+   @NAME    : enum
+   @author  : 清风
+   @desc    : 为 MySQL ENUM/SET 列生成枚举类型
+   @time    : 2026/10/17
+*/
+
+// buildEnum 为 ENUM/SET 列构建枚举类型信息，列类型不是 ENUM/SET 时返回 nil。
+// 枚举类型名为 模型名+字段名（如 OrderStatus），SET 列的元素类型使用列名的单数形式，
+// 切片类型使用列名（如 UserTag、UserTags）。类型名与 taken 中的名称重复时追加 Enum 后缀，
+// 常量名（如 OrderStatusPaid）重复时追加序号，生成的名称都会加入 taken
+func buildEnum(table *config.TableInfo, field *config.FieldInfo, taken map[string]bool, namer *naming.Namer) *config.EnumInfo {
+	kind, values, ok := utils.ParseEnumValues(field.ColumnType)
+	if !ok || len(values) == 0 {
+		return nil
+	}
+
+	enum := &config.EnumInfo{
+		Comment:  field.Comment,
+		Nullable: field.IsNullable,
+	}
+	if kind == "set" {
+		enum.Type = uniqueTypeName(table.ModelName+namer.Pascal(inflection.Singular(field.Name)), taken)
+		enum.SetType = table.ModelName + field.GoName
+		if enum.SetType == enum.Type {
+			enum.SetType += "Set"
+		}
+		enum.SetType = uniqueTypeName(enum.SetType, taken)
+	} else {
+		enum.Type = uniqueTypeName(table.ModelName+field.GoName, taken)
+	}
+
+	// 常量与类型在同一个包中，转换后重复的常量名追加序号
+	for _, value := range values {
+		base := enum.Type + enumValueName(value)
+		name := base
+		for n := 2; taken[name]; n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[name] = true
+		enum.Values = append(enum.Values, config.EnumValue{Name: name, Value: value})
+	}
+	return enum
+}
+
+// uniqueTypeName 返回未被占用的类型名并标记为已占用
+func uniqueTypeName(name string, taken map[string]bool) string {
+	for taken[name] {
+		name += "Enum"
+	}
+	taken[name] = true
+	return name
+}
+
+// enumValueName 将枚举值转换为常量名后缀：按非字母数字的字符拆分，每段首字母大写，空值为 Empty
+func enumValueName(value string) string {
+	var sb strings.Builder
+	upperNext := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "Empty"
+	}
+	return sb.String()
+}
+
+// tableEnums 返回表中所有字段的枚举类型
+func tableEnums(table *config.TableInfo) []*config.EnumInfo {
+	var enums []*config.EnumInfo
+	for _, field := range table.Fields {
+		if field.Enum != nil {
+			enums = append(enums, field.Enum)
+		}
+	}
+	return enums
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestEnumValueName(t *testing.T) {
+	tests := map[string]string{
+		"paid":            "Paid",
+		"pending payment": "PendingPayment",
+		"in-progress":     "InProgress",
+		"a_b c":           "ABC",
+		"1st":             "1st",
+		"已支付":             "已支付",
+		"":                "Empty",
+		"--":              "Empty",
+	}
+	for value, want := range tests {
+		if got := enumValueName(value); got != want {
+			t.Errorf("enumValueName(%q) = %q，期望 %q", value, got, want)
+		}
+	}
+}
+
+// enumNames 返回枚举常量名，便于比较
+func enumNames(enum *config.EnumInfo) []string {
+	var names []string
+	for _, value := range enum.Values {
+		names = append(names, value.Name+"="+value.Value)
+	}
+	return names
+}
+
+func TestBuildEnum(t *testing.T) {
+	namer := newNamer(&config.Config{})
+	table := &config.TableInfo{Name: "orders", ModelName: "Orders"}
+
+	t.Run("常量名", func(t *testing.T) {
+		field := &config.FieldInfo{Name: "status", GoName: "Status", ColumnType: "enum('pending payment','in-progress','1st','已支付','')"}
+		enum := buildEnum(table, field, map[string]bool{"Orders": true}, namer)
+		if enum.Type != "OrdersStatus" || enum.SetType != "" {
+			t.Errorf("类型为 %q/%q，期望 OrdersStatus", enum.Type, enum.SetType)
+		}
+		want := []string{
+			"OrdersStatusPendingPayment=pending payment",
+			"OrdersStatusInProgress=in-progress",
+			"OrdersStatus1st=1st",
+			"OrdersStatus已支付=已支付",
+			"OrdersStatusEmpty=",
+		}
+		if got := enumNames(enum); !reflect.DeepEqual(got, want) {
+			t.Errorf("得到 %q，期望 %q", got, want)
+		}
+	})
+
+	t.Run("转换后重复的常量名", func(t *testing.T) {
+		field := &config.FieldInfo{Name: "kind", GoName: "Kind", ColumnType: "enum('a-b','a_b','A B','ab2','AB2')"}
+		enum := buildEnum(table, field, map[string]bool{}, namer)
+		want := []string{
+			"OrdersKindAB=a-b",
+			"OrdersKindAB2=a_b",
+			"OrdersKindAB3=A B",
+			"OrdersKindAb2=ab2",
+			"OrdersKindAB22=AB2",
+		}
+		if got := enumNames(enum); !reflect.DeepEqual(got, want) {
+			t.Errorf("得到 %q，期望 %q", got, want)
+		}
+	})
+
+	t.Run("与已有类型重复", func(t *testing.T) {
+		// OrdersStatus 为模型名，OrdersStatusEnumPaid 为其他枚举的类型名
+		taken := map[string]bool{"OrdersStatus": true, "OrdersStatusEnumPaid": true}
+		field := &config.FieldInfo{Name: "status", GoName: "Status", ColumnType: "enum('paid','unpaid')"}
+		enum := buildEnum(table, field, taken, namer)
+		if enum.Type != "OrdersStatusEnum" {
+			t.Errorf("类型为 %q，期望 OrdersStatusEnum", enum.Type)
+		}
+		want := []string{"OrdersStatusEnumPaid2=paid", "OrdersStatusEnumUnpaid=unpaid"}
+		if got := enumNames(enum); !reflect.DeepEqual(got, want) {
+			t.Errorf("得到 %q，期望 %q", got, want)
+		}
+
+		// 之后的枚举类型不能与已生成的常量重名
+		other := buildEnum(&config.TableInfo{Name: "orders_status", ModelName: "OrdersStatusEnum"},
+			&config.FieldInfo{Name: "unpaid", GoName: "Unpaid", ColumnType: "enum('y','n')"}, taken, namer)
+		if other.Type != "OrdersStatusEnumUnpaidEnum" {
+			t.Errorf("类型为 %q，期望 OrdersStatusEnumUnpaidEnum", other.Type)
+		}
+		for _, name := range []string{"OrdersStatusEnum", "OrdersStatusEnumPaid2", "OrdersStatusEnumUnpaid", "OrdersStatusEnumUnpaidEnum", "OrdersStatusEnumUnpaidEnumY"} {
+			if !taken[name] {
+				t.Errorf("%s 没有标记为已占用", name)
+			}
+		}
+	})
+
+	t.Run("SET 列", func(t *testing.T) {
+		taken := map[string]bool{}
+		tags := buildEnum(table, &config.FieldInfo{Name: "tags", GoName: "Tags", ColumnType: "set('new','hot')", IsNullable: true}, taken, namer)
+		if tags.Type != "OrdersTag" || tags.SetType != "OrdersTags" || !tags.Nullable {
+			t.Errorf("类型为 %q/%q，期望 OrdersTag/OrdersTags", tags.Type, tags.SetType)
+		}
+		// 单复数相同时切片类型追加 Set
+		info := buildEnum(table, &config.FieldInfo{Name: "info", GoName: "Info", ColumnType: "set('a')"}, taken, namer)
+		if info.Type != "OrdersInfo" || info.SetType != "OrdersInfoSet" {
+			t.Errorf("类型为 %q/%q，期望 OrdersInfo/OrdersInfoSet", info.Type, info.SetType)
+		}
+	})
+
+	t.Run("非枚举列", func(t *testing.T) {
+		for _, columnType := range []string{"varchar(16)", "enum()", "enumeration"} {
+			if enum := buildEnum(table, &config.FieldInfo{Name: "x", GoName: "X", ColumnType: columnType}, map[string]bool{}, namer); enum != nil {
+				t.Errorf("%s 不应生成枚举类型", columnType)
+			}
+		}
+	})
+}
+
+func TestGeneratedEnumScanValue(t *testing.T) {
+	tables := []*config.TableInfo{{
+		Name: "orders",
+		Fields: []config.FieldInfo{
+			{Name: "id", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+			{Name: "status", Type: "string", ColumnType: "enum('pending payment','in-progress','1st','已支付')"},
+			{Name: "tags", Type: "*string", ColumnType: "set('new','hot')", IsNullable: true},
+		},
+		Indexes: []config.IndexInfo{{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true}},
+	}}
+	output := runGenerated(t, tables, &config.Config{}, `package main
+
+import (
+	"fmt"
+
+	"MODULE/orm/model"
+)
+
+func main() {
+	for _, status := range model.OrdersStatus("").Values() {
+		value, err := status.Value()
+		var fromString, fromBytes model.OrdersStatus
+		fromString.Scan(value)
+		fromBytes.Scan([]byte(value.(string)))
+		fmt.Printf("status %q %v %v %v\n", value, err, fromString == status, fromBytes == status)
+	}
+	fmt.Println(model.OrdersStatus1st, model.OrdersStatus已支付)
+	_, err := model.OrdersStatus("paid").Value()
+	fmt.Println("invalid", err)
+
+	var tags model.OrdersTags
+	tags.Scan([]byte("new,hot"))
+	value, err := tags.Value()
+	fmt.Printf("tags %d %q %v %v\n", len(tags), value, err, tags.Contains(model.OrdersTagHot))
+	tags.Scan(nil)
+	value, err = tags.Value()
+	fmt.Println("null", tags == nil, value, err)
+	tags.Scan("")
+	value, err = tags.Value()
+	fmt.Printf("empty %v %q %v\n", tags == nil, value, err)
+}
+`)
+	want := []string{
+		`status "pending payment" <nil> true true`,
+		`status "in-progress" <nil> true true`,
+		`status "1st" <nil> true true`,
+		`status "已支付" <nil> true true`,
+		"1st 已支付",
+		`invalid 无效的 OrdersStatus 值: "paid"`,
+		`tags 2 "new,hot" <nil> true`,
+		"null true <nil> <nil>",
+		`empty false "" <nil>`,
+	}
+	for _, line := range want {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("输出中缺少 %q，输出:\n%s", line, output)
+		}
+	}
+}
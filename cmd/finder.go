@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : finder
+   @author  : 清风
+   @desc    : 根据主键和索引生成 FindByXxx、ListByXxx 等查询方法
+   @time    : 2026/10/17
+*/
+
+// finder 根据一个索引生成的查询方法
+type finder struct {
+	Name    string   // 方法名后缀，如 ID、TenantIDAndCode
+	Index   string   // 索引描述，用于注释
+	Primary bool     // 是否是主键，主键额外生成 DeleteByXxx、UpdateByXxx
+	Unique  bool     // 是否唯一，唯一索引生成 FindByXxx 返回单条记录，否则生成 ListByXxx
+	Params  string   // 参数列表，如 tenantID int64, code string
+	Args    string   // 查询参数，如 tenantID, code
+	Where   string   // 查询条件，如 ? = ? AND ? = ?，列名通过 Conds 中的 clause.Column 传入
+	Conds   string   // 查询条件的参数，依次为列和对应的查询参数
+	Columns []string // 索引列
+}
+
+// reservedParams 生成的查询方法中已使用的变量名，参数名不能与之相同
+var reservedParams = map[string]bool{
+	"q": true, "ctx": true, "result": true, "results": true, "err": true, "values": true,
+	"db": true, "cursor": true, "size": true, "next": true, "last": true, "order": true,
+}
+
+// buildFinders 根据表的主键、唯一索引和普通索引生成查询方法，相同列组成的索引只生成一次，
+// 已有唯一索引的列组合不再生成 ListByXxx。包含表达式等无法对应到字段的索引会被跳过
+func buildFinders(table *config.TableInfo, cfg *config.Config, modelPackage string) []finder {
+	namer := newNamer(cfg)
+	fields := make(map[string]*config.FieldInfo, len(table.Fields))
+	for i := range table.Fields {
+		fields[table.Fields[i].Name] = &table.Fields[i]
+	}
+
+	// 主键、唯一索引优先
+	indexes := make([]config.IndexInfo, 0, len(table.Indexes))
+	for _, uniq := range []bool{true, false} {
+		for _, index := range table.Indexes {
+			if index.IsUniq == uniq {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+
+	var finders []finder
+	seen := make(map[string]bool)
+	for _, index := range indexes {
+		if len(index.Fields) == 0 {
+			continue
+		}
+		var names, params, args, where, conds []string
+		valid := true
+		for _, column := range index.Fields {
+			field, ok := fields[column]
+			if !ok {
+				valid = false
+				break
+			}
+			param := paramName(namer.Camel(field.Name))
+			names = append(names, field.GoName)
+			params = append(params, param+" "+valueType(*field, modelPackage))
+			args = append(args, param)
+			where = append(where, "? = ?")
+			conds = append(conds, columnRef(field.Name), param)
+		}
+		key := strings.Join(index.Fields, ",")
+		if !valid || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		desc := "索引 " + index.Name
+		switch {
+		case index.IsPK:
+			desc = "主键"
+		case index.IsUniq:
+			desc = "唯一索引 " + index.Name
+		}
+		finders = append(finders, finder{
+			Name:    strings.Join(names, "And"),
+			Index:   desc,
+			Primary: index.IsPK,
+			Unique:  index.IsUniq,
+			Params:  strings.Join(params, ", "),
+			Args:    strings.Join(args, ", "),
+			Where:   strings.Join(where, " AND "),
+			Conds:   strings.Join(conds, ", "),
+			Columns: index.Fields,
+		})
+	}
+	return finders
+}
+
+// paramName 将列名转换的小驼峰名称调整为合法的参数名：以数字开头时加 v 前缀，是关键字或与方法中的变量重名时加 _ 后缀
+func paramName(name string) string {
+	switch {
+	case token.IsKeyword(name) || reservedParams[name]:
+		return name + "_"
+	case !token.IsIdentifier(name):
+		return "v" + name
+	}
+	return name
+}
+
+// conflictFinder 返回 Upsert 使用的冲突列：优先使用主键，没有主键时使用第一个唯一索引，都没有时返回 nil。
+// PostgreSQL、SQLite 只处理指定列上的冲突，以主键作为冲突列时按主键插入或更新记录
+func conflictFinder(finders []finder) *finder {
+	var unique *finder
+	for i := range finders {
+		switch {
+		case finders[i].Primary:
+			return &finders[i]
+		case finders[i].Unique && unique == nil:
+			unique = &finders[i]
+		}
+	}
+	return unique
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestBuildFindersUseColumnExpressions(t *testing.T) {
+	table := &config.TableInfo{
+		Name: "accounts",
+		Fields: []config.FieldInfo{
+			{Name: "id", GoName: "ID", Type: "int64"},
+			{Name: "range", GoName: "Range", Type: "int"},
+			{Name: "order", GoName: "Order", Type: "string"},
+		},
+		Indexes: []config.IndexInfo{
+			{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+			{Name: "uk_range_order", Fields: []string{"range", "order"}, IsUniq: true},
+		},
+	}
+	finders := buildFinders(table, &config.Config{}, "model")
+	if len(finders) != 2 {
+		t.Fatalf("生成了 %d 个查询方法，期望 2 个", len(finders))
+	}
+	got := finders[1]
+	if got.Where != "? = ? AND ? = ?" {
+		t.Errorf("Where = %q，期望列名通过参数传入", got.Where)
+	}
+	wantConds := `clause.Column{Table: clause.CurrentTable, Name: "range"}, range_, clause.Column{Table: clause.CurrentTable, Name: "order"}, order_`
+	if got.Conds != wantConds {
+		t.Errorf("Conds = %q，期望 %q", got.Conds, wantConds)
+	}
+}
+
+func TestConflictFinder(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexes []config.IndexInfo
+		want    string // 冲突列，没有时为空
+	}{
+		{
+			name: "优先使用主键",
+			indexes: []config.IndexInfo{
+				{Name: "uk_email", Fields: []string{"email"}, IsUniq: true},
+				{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+			},
+			want: "id",
+		},
+		{
+			name: "没有主键时使用第一个唯一索引",
+			indexes: []config.IndexInfo{
+				{Name: "idx_name", Fields: []string{"name"}},
+				{Name: "uk_email", Fields: []string{"email"}, IsUniq: true},
+				{Name: "uk_name_email", Fields: []string{"name", "email"}, IsUniq: true},
+			},
+			want: "email",
+		},
+		{
+			name:    "只有普通索引",
+			indexes: []config.IndexInfo{{Name: "idx_name", Fields: []string{"name"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &config.TableInfo{
+				Name: "users",
+				Fields: []config.FieldInfo{
+					{Name: "id", GoName: "ID", Type: "int64"},
+					{Name: "name", GoName: "Name", Type: "string"},
+					{Name: "email", GoName: "Email", Type: "string"},
+				},
+				Indexes: tt.indexes,
+			}
+			got := ""
+			if conflict := conflictFinder(buildFinders(table, &config.Config{}, "model")); conflict != nil {
+				got = strings.Join(conflict.Columns, ",")
+			}
+			if got != tt.want {
+				t.Errorf("冲突列为 %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratedConditionsQuoteColumns(t *testing.T) {
+	tables := []*config.TableInfo{{
+		Name: "accounts",
+		Fields: []config.FieldInfo{
+			{Name: "id", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+			{Name: "range", Type: "int", ColumnType: "int"},
+			{Name: "order", Type: "string", ColumnType: "varchar(32)"},
+		},
+		Indexes: []config.IndexInfo{
+			{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+			{Name: "uk_range_order", Fields: []string{"range", "order"}, IsUniq: true},
+		},
+	}}
+	output := runGenerated(t, tables, &config.Config{}, `package main
+
+import (
+	"context"
+
+	"MODULE/orm/query"
+)
+
+func main() {
+	db := dryRun()
+	query.NewAccountsQuery(db).WhereRange(1).Find()
+	query.NewAccountsQuery(db).WhereRangeIn([]int{1, 2}).Find()
+	query.NewAccountsQuery(db).WhereRangeBetween(1, 9).Find()
+	query.NewAccountsQuery(db).WhereOrderLike("a").Find()
+	query.NewAccountsQuery(db).FindByRangeAndOrder(context.Background(), 1, "a")
+}
+`)
+	want := []string{
+		"SELECT * FROM `accounts` WHERE `accounts`.`range` = 1",
+		"SELECT * FROM `accounts` WHERE `accounts`.`range` IN (1,2)",
+		"SELECT * FROM `accounts` WHERE `accounts`.`range` BETWEEN 1 AND 9",
+		"SELECT * FROM `accounts` WHERE `accounts`.`order` LIKE '%a%'",
+		"SELECT * FROM `accounts` WHERE `accounts`.`range` = 1 AND `accounts`.`order` = 'a' LIMIT 1",
+	}
+	for _, sql := range want {
+		if !strings.Contains(output, sql) {
+			t.Errorf("生成代码执行的 SQL 中缺少 %q，输出:\n%s", sql, output)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"strconv"
+	"strings"
+)
+
+/*
+   @NAME    : format
+   @author  : 清风
+   @desc    : 格式化生成的代码，失败时输出出错位置附近的模板输出
+   @time    : 2026/10/17
+*/
+
+// formatContext 格式化失败时输出出错行前后的行数
+const formatContext = 3
+
+// formatSource 格式化生成的代码，失败时错误信息中包含文件名、出错的行以及前后几行模板输出
+func formatSource(path string, src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err == nil {
+		return formatted, nil
+	}
+
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return nil, fmt.Errorf("格式化代码失败（%s）: %v", path, err)
+	}
+	line := list[0].Pos.Line
+	return nil, fmt.Errorf("格式化代码失败（%s 第 %d 行）: %v\n%s", path, line, err, sourceExcerpt(src, line))
+}
+
+// sourceExcerpt 返回第 line 行前后的代码，带行号，出错的行以 > 标记
+func sourceExcerpt(src []byte, line int) string {
+	lines := strings.Split(string(bytes.TrimRight(src, "\n")), "\n")
+	start, end := line-formatContext, line+formatContext
+	if start < 1 {
+		start = 1
+	}
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var sb strings.Builder
+	for i := start; i <= end; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&sb, "%s %4d | %s\n", marker, i, lines[i-1])
+	}
+	return sb.String()
+}
+
+// quote 将 parts 拼接后转换为 Go 字符串字面量，模板通过它输出表名、列名和包含列名的 SQL 片段，
+// 名称中的引号、反斜杠等不会破坏生成的代码
+func quote(parts ...string) string {
+	return strconv.Quote(strings.Join(parts, ""))
+}
+
+// columnRef 返回引用当前表中 name 列的 clause.Column 代码，作为 SQL 参数时 gorm 会按数据库方言为列名加引号，
+// range、order 等关键字作为列名时也能正确查询
+func columnRef(name string) string {
+	return "clause.Column{Table: clause.CurrentTable, Name: " + quote(name) + "}"
+}
@@ -3,8 +3,6 @@ package cmd
 import (
 	"bytes"
 	"fmt"
-	"go/format"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -22,23 +20,36 @@ import (
 */
 
 // GenerateModel 生成 Model 代码
-func GenerateModel(table *config.TableInfo, cfg *config.Config) error {
+func GenerateModel(table *config.TableInfo, cfg *config.Config, out Output) error {
 	// 获取包名（从目录路径中获取）
-	dirParts := strings.Split(strings.Trim(cfg.Output.ModelDir, "/"), "/")
-	var packageName string
-	if len(dirParts) > 0 {
-		packageName = dirParts[len(dirParts)-1]
-	} else {
-		packageName = "model" // 默认包名
+	packageName := dirPackage(cfg.Output.ModelDir, "model")
+
+	// 字段类型需要导入的包，gorm 已由模板导入
+	stdImports, imports := fieldImports(table.Fields, "gorm.io/gorm")
+
+	// 枚举类型的方法需要的包
+	enums := tableEnums(table)
+	if len(enums) > 0 {
+		stdImports = mergeImports(stdImports, "database/sql/driver", "encoding/json", "fmt")
+		for _, enum := range enums {
+			if enum.SetType != "" {
+				stdImports = mergeImports(stdImports, "strings")
+				break
+			}
+		}
 	}
 
 	// 准备模板数据
 	data := map[string]interface{}{
-		"Package":   packageName,
-		"TableName": table.Name,
-		"Comment":   table.Comment,
-		"Fields":    table.Fields,
-		"Relations": table.Relations,
+		"Package":    packageName,
+		"TableName":  table.Name,
+		"ModelName":  table.ModelName,
+		"Comment":    table.Comment,
+		"Fields":     table.Fields,
+		"Relations":  table.Relations,
+		"Enums":      enums,
+		"StdImports": stdImports,
+		"Imports":    imports,
 	}
 
 	// 加载模板
@@ -53,6 +64,8 @@ func GenerateModel(table *config.TableInfo, cfg *config.Config) error {
 		"Contains":       strings.Contains,
 		"not":            func(b bool) bool { return !b },
 		"BuildFieldTags": utils.BuildFieldTags,
+		"EscapeTag":      utils.EscapeTag,
+		"Quote":          quote,
 	})
 
 	// 如果指定了自定义模板，则使用自定义模板
@@ -80,37 +93,22 @@ func GenerateModel(table *config.TableInfo, cfg *config.Config) error {
 		return fmt.Errorf("生成代码失败: %v", err)
 	}
 
-	// 格式化代码
-	formatted, err := format.Source(buf.Bytes())
-	if err != nil {
-		return fmt.Errorf("格式化代码失败: %v", err)
-	}
-
-	// 创建输出目录
 	outputDir := cfg.Output.ModelDir
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %v", err)
-	}
 
-	// 生成文件名
-	var filename string
-	switch cfg.Style {
-	case "snake":
-		filename = fmt.Sprintf("%s.go", utils.ToSnake(table.Name))
-	case "camel":
-		filename = fmt.Sprintf("%s.go", utils.ToCamel(table.Name))
-	case "pascal":
-		filename = fmt.Sprintf("%s.go", utils.ToCamel(table.Name))
-	default:
-		filename = fmt.Sprintf("%s.go", table.Name)
+	outputFile := filepath.Join(outputDir, tableFileName(table, cfg))
+
+	// 保留已有文件保护区域中手写的代码
+	content, err := preserveRegions(outputFile, buf.Bytes())
+	if err != nil {
+		return err
 	}
 
-	// 写入文件
-	outputFile := filepath.Join(outputDir, filename)
-	if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
-		return fmt.Errorf("写入文件失败: %v", err)
+	// 格式化代码
+	formatted, err := formatSource(outputFile, content)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("  生成文件: %s\n", outputFile)
-	return nil
+	// 写入文件
+	return out.WriteFile(outputFile, formatted)
 }
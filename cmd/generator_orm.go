@@ -10,26 +10,50 @@ package cmd
 import (
 	"bytes"
 	"fmt"
-	"go/format"
-	"os"
 	"path/filepath"
-	"strings"
 	"text/template"
 
 	"github.com/tokmz/zero/config"
 	tm "github.com/tokmz/zero/template"
 )
 
+// ormDriver 生成的 orm.go 中连接数据库使用的驱动
+type ormDriver struct {
+	Name    string // 构造函数名中的数据库名称，如 Mysql 对应 NewMysql
+	Title   string // 注释中的数据库名称
+	Import  string // 驱动包的导入路径
+	Package string // 驱动包名
+	DSN     string // 连接串格式
+}
+
+// ormDrivers 内置驱动生成代码时使用的驱动包，SQLite 使用不依赖 cgo 的驱动
+var ormDrivers = map[string]ormDriver{
+	"mysql": {
+		Name: "Mysql", Title: "MySQL", Import: "gorm.io/driver/mysql", Package: "mysql",
+		DSN: "user:pass@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local",
+	},
+	"postgres": {
+		Name: "Postgres", Title: "PostgreSQL", Import: "gorm.io/driver/postgres", Package: "postgres",
+		DSN: "host=localhost user=gorm password=gorm dbname=gorm port=5432 sslmode=disable",
+	},
+	"sqlite": {
+		Name: "SQLite", Title: "SQLite", Import: "github.com/glebarez/sqlite", Package: "sqlite",
+		DSN: "file:app.db?_pragma=foreign_keys(1)",
+	},
+}
+
+// schemaDriver 返回表结构对应的数据库驱动：DDL 文件按 MySQL 语法解析，未指定驱动时默认为 MySQL
+func schemaDriver(cfg *config.Config) string {
+	if cfg.DDL != "" || cfg.Driver == "" {
+		return "mysql"
+	}
+	return cfg.Driver
+}
+
 // GenerateOrm 生成 ORM 代码
-func GenerateOrm(tables []*config.TableInfo, cfg *config.Config) error {
+func GenerateOrm(tables []*config.TableInfo, cfg *config.Config, out Output) error {
 	// 获取包名（从目录路径中获取）
-	dirParts := strings.Split(strings.Trim(cfg.Output.OrmDir, "/"), "/")
-	var packageName string
-	if len(dirParts) > 0 {
-		packageName = dirParts[len(dirParts)-1]
-	} else {
-		packageName = "orm" // 默认包名
-	}
+	packageName := dirPackage(cfg.Output.OrmDir, "orm")
 
 	// 准备模板数据
 	data := map[string]interface{}{
@@ -37,6 +61,10 @@ func GenerateOrm(tables []*config.TableInfo, cfg *config.Config) error {
 		"Tables":        tables,
 		"EnableTracing": cfg.EnableTracing,
 	}
+	// 自定义驱动只生成通用的 Open，由使用者传入 Dialector
+	if driver, ok := ormDrivers[schemaDriver(cfg)]; ok {
+		data["Driver"] = driver
+	}
 
 	// 加载模板
 	tmpl := template.New("orm")
@@ -68,23 +96,12 @@ func GenerateOrm(tables []*config.TableInfo, cfg *config.Config) error {
 	}
 
 	// 格式化代码
-	formatted, err := format.Source(buf.Bytes())
+	outputFile := filepath.Join(cfg.Output.OrmDir, "orm.go")
+	formatted, err := formatSource(outputFile, buf.Bytes())
 	if err != nil {
-		return fmt.Errorf("格式化代码失败: %v", err)
-	}
-
-	// 创建输出目录
-	outputDir := cfg.Output.OrmDir
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %v", err)
+		return err
 	}
 
 	// 写入文件
-	outputFile := filepath.Join(outputDir, "orm.go")
-	if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
-		return fmt.Errorf("写入文件失败: %v", err)
-	}
-
-	fmt.Printf("  生成文件: %s\n", outputFile)
-	return nil
+	return out.WriteFile(outputFile, formatted)
 }
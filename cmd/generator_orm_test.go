@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestSchemaDriver(t *testing.T) {
+	tests := []struct {
+		cfg  config.Config
+		want string
+	}{
+		{config.Config{}, "mysql"},
+		{config.Config{Driver: "postgres"}, "postgres"},
+		{config.Config{Driver: "sqlite", DDL: "schema.sql"}, "mysql"},
+	}
+	for _, tt := range tests {
+		if got := schemaDriver(&tt.cfg); got != tt.want {
+			t.Errorf("schemaDriver(%+v) = %q，期望 %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateOrmDriver(t *testing.T) {
+	tests := []struct {
+		driver  string
+		want    []string
+		notWant []string
+	}{
+		{
+			driver:  "mysql",
+			want:    []string{`"gorm.io/driver/mysql"`, "func NewMysql(c Config) (*gorm.DB, error)", "Open(mysql.Open, c)"},
+			notWant: []string{"driver/postgres", "glebarez"},
+		},
+		{
+			driver:  "postgres",
+			want:    []string{`"gorm.io/driver/postgres"`, "func NewPostgres(c Config) (*gorm.DB, error)", "Open(postgres.Open, c)"},
+			notWant: []string{"driver/mysql", "NewMysql", "glebarez"},
+		},
+		{
+			driver:  "sqlite",
+			want:    []string{`"github.com/glebarez/sqlite"`, "func NewSQLite(c Config) (*gorm.DB, error)", "Open(sqlite.Open, c)"},
+			notWant: []string{"driver/mysql", "NewMysql", "driver/postgres"},
+		},
+		{
+			// 自定义驱动只生成通用的 Open
+			driver:  "fake",
+			want:    []string{"func Open(dialector func(dsn string) gorm.Dialector, c Config) (*gorm.DB, error)"},
+			notWant: []string{"driver/mysql", "driver/postgres", "glebarez", "NewMysql"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			cfg := &config.Config{Driver: tt.driver, Output: config.OutputConfig{OrmDir: "orm"}}
+			out := newMemoryOutput()
+			if err := GenerateOrm(nil, cfg, out); err != nil {
+				t.Fatalf("GenerateOrm: %v", err)
+			}
+			content := string(out.files[filepath.Join("orm", "orm.go")])
+			for _, snippet := range tt.want {
+				if !strings.Contains(content, snippet) {
+					t.Errorf("orm.go 中缺少 %q", snippet)
+				}
+			}
+			for _, snippet := range tt.notWant {
+				if strings.Contains(content, snippet) {
+					t.Errorf("orm.go 中不应包含 %q", snippet)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratedSQLiteConnector(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "app.db")
+	tables := []*config.TableInfo{{
+		Name: "users",
+		Fields: []config.FieldInfo{
+			{Name: "id", Type: "int64", ColumnType: "INTEGER", IsPrimary: true},
+			{Name: "name", Type: "string", ColumnType: "TEXT"},
+		},
+		Indexes: []config.IndexInfo{{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true}},
+	}}
+	output := runGenerated(t, tables, &config.Config{Driver: "sqlite"}, `package main
+
+import (
+	"fmt"
+
+	"MODULE/orm"
+	"MODULE/orm/model"
+	"MODULE/orm/query"
+)
+
+func main() {
+	db, err := orm.NewSQLite(orm.Config{Master: `+"`"+dsn+"`"+`})
+	if err != nil {
+		panic(err)
+	}
+	if err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)").Error; err != nil {
+		panic(err)
+	}
+	if err := query.NewUsersQuery(db).Create(&model.Users{Name: "a"}); err != nil {
+		panic(err)
+	}
+	count, err := query.NewUsersQuery(db).WhereName("a").Count()
+	fmt.Println("count", count, err)
+}
+`)
+	if !strings.Contains(output, "count 1 <nil>") {
+		t.Errorf("使用生成的 NewSQLite 读写数据库失败，输出:\n%s", output)
+	}
+}
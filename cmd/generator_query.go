@@ -3,8 +3,6 @@ package cmd
 import (
 	"bytes"
 	"fmt"
-	"go/format"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -22,35 +20,51 @@ import (
 */
 
 // GenerateQuery 生成 Query 代码
-func GenerateQuery(table *config.TableInfo, cfg *config.Config) error {
+func GenerateQuery(table *config.TableInfo, cfg *config.Config, out Output) error {
 	// 获取包名（从目录路径中获取）
-	dirParts := strings.Split(strings.Trim(cfg.Output.QueryDir, "/"), "/")
-	var packageName string
-	if len(dirParts) > 0 {
-		packageName = dirParts[len(dirParts)-1]
-	} else {
-		packageName = "query" // 默认包名
-	}
+	packageName := dirPackage(cfg.Output.QueryDir, "query")
 
 	// 获取 model 包名
-	modelDirParts := strings.Split(strings.Trim(cfg.Output.ModelDir, "/"), "/")
-	var modelPackage string
-	if len(modelDirParts) > 0 {
-		modelPackage = modelDirParts[len(modelDirParts)-1]
-	} else {
-		modelPackage = "model" // 默认包名
+	modelPackage := dirPackage(cfg.Output.ModelDir, "model")
+
+	// 查询方法参数类型需要导入的包，context、gorm 已由模板导入
+	stdImports, imports := valueImports(table.Fields, "context", "gorm.io/gorm", "gorm.io/gorm/clause")
+	mysql := isMySQL(cfg)
+	for _, field := range table.Fields {
+		if field.JSON && mysql {
+			// JSON_CONTAINS 查询方法需要编码参数
+			stdImports = mergeImports(stdImports, "encoding/json")
+			break
+		}
 	}
 
+	// 类型安全字段、乐观锁错误和游标编码都在 orm 包中
+	ormPath, ormPackage := ormImport(cfg)
+	imports = mergeImports(imports, ormPath)
+	finders := buildFinders(table, cfg, modelPackage)
+	cursor := buildCursor(table, cfg, modelPackage)
+
 	// 准备模板数据
 	data := map[string]interface{}{
 		"Package":      packageName,
 		"TableName":    table.Name,
+		"ModelName":    table.ModelName,
+		"SoftDelete":   table.SoftDelete,
+		"Restore":      buildRestore(table, cfg),
+		"Version":      table.Version,
+		"OrmPackage":   ormPackage,
 		"Comment":      table.Comment,
 		"Fields":       table.Fields,
 		"Relations":    table.Relations,
+		"Finders":      finders,
+		"Conflict":     conflictFinder(finders),
+		"Cursor":       cursor,
+		"MySQL":        mysql,
 		"ModelPath":    strings.TrimPrefix(cfg.Output.ModelDir, "./"),
 		"ModuleName":   cfg.ModuleName,
 		"ModelPackage": modelPackage,
+		"StdImports":   stdImports,
+		"Imports":      imports,
 	}
 
 	// 加载模板
@@ -65,6 +79,17 @@ func GenerateQuery(table *config.TableInfo, cfg *config.Config) error {
 		"Contains":       strings.Contains,
 		"not":            func(b bool) bool { return !b },
 		"BuildFieldTags": utils.BuildFieldTags,
+		"Quote":          quote,
+		"Column":         columnRef,
+		"ValueType": func(field config.FieldInfo) string {
+			return valueType(field, modelPackage)
+		},
+		"FieldType": func(field config.FieldInfo) string {
+			return ormPackage + "." + fieldKind(field, modelPackage)
+		},
+		"NewField": func(field config.FieldInfo) string {
+			return ormPackage + ".New" + fieldKind(field, modelPackage)
+		},
 	})
 
 	// 如果指定了自定义模板，则使用自定义模板
@@ -93,37 +118,49 @@ func GenerateQuery(table *config.TableInfo, cfg *config.Config) error {
 		return fmt.Errorf("生成代码失败: %v", err)
 	}
 
-	// 格式化代码
-	formatted, err := format.Source(buf.Bytes())
+	outputDir := cfg.Output.QueryDir
+
+	outputFile := filepath.Join(outputDir, tableFileName(table, cfg))
+
+	// 保留已有文件保护区域中手写的代码
+	content, err := preserveRegions(outputFile, buf.Bytes())
 	if err != nil {
-		return fmt.Errorf("格式化代码失败: %v", err)
+		return err
 	}
 
-	// 创建输出目录
-	outputDir := cfg.Output.QueryDir
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %v", err)
+	// 格式化代码
+	formatted, err := formatSource(outputFile, content)
+	if err != nil {
+		return err
 	}
 
-	// 生成文件名
-	var filename string
-	switch cfg.Style {
-	case "snake":
-		filename = fmt.Sprintf("%s.go", utils.ToSnake(table.Name))
-	case "camel":
-		filename = fmt.Sprintf("%s.go", utils.ToCamel(table.Name))
-	case "pascal":
-		filename = fmt.Sprintf("%s.go", utils.ToCamel(table.Name))
-	default:
-		filename = fmt.Sprintf("%s.go", table.Name)
+	// 写入文件
+	return out.WriteFile(outputFile, formatted)
+}
+
+// valueType 返回字段非空值在 query 包中使用的类型，model 包中生成的枚举类型需要加上包名
+func valueType(field config.FieldInfo, modelPackage string) string {
+	if field.Enum == nil {
+		return field.ValueType
 	}
+	return modelPackage + "." + field.ValueType
+}
 
-	// 写入文件
-	outputFile := filepath.Join(outputDir, filename)
-	if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
-		return fmt.Errorf("写入文件失败: %v", err)
+// fieldKind 返回字段在 XxxFields 中使用的 orm 包字段类型：JSON、SET 列为 Field，字符串为 String，
+// 数字和时间为 Ordered[T]，其他类型（bool、枚举、自定义类型等）为 Comparable[T]
+func fieldKind(field config.FieldInfo, modelPackage string) string {
+	switch {
+	case field.JSON || (field.Enum != nil && field.Enum.SetType != ""):
+		return "Field"
+	case field.ValueType == "string":
+		return "String"
+	case field.Numeric || field.ValueType == "time.Time":
+		return "Ordered[" + field.ValueType + "]"
 	}
+	return "Comparable[" + valueType(field, modelPackage) + "]"
+}
 
-	fmt.Printf("  生成文件: %s\n", outputFile)
-	return nil
+// isMySQL 判断生成的代码是否用于 MySQL
+func isMySQL(cfg *config.Config) bool {
+	return schemaDriver(cfg) == "mysql"
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// typedFieldTables 返回包含各种类型的可为空列的表结构
+func typedFieldTables() []*config.TableInfo {
+	return []*config.TableInfo{{
+		Name: "users",
+		Fields: []config.FieldInfo{
+			{Name: "id", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+			{Name: "name", Type: "string", ColumnType: "varchar(32)"},
+			{Name: "nickname", Type: "*string", ColumnType: "varchar(32)", IsNullable: true},
+			{Name: "age", Type: "*int32", ColumnType: "int", IsNullable: true},
+			{Name: "hits", Type: "*uint64", ColumnType: "bigint unsigned", IsNullable: true},
+			{Name: "score", Type: "*float64", ColumnType: "double", IsNullable: true},
+			{Name: "active", Type: "*bool", ColumnType: "tinyint(1)", IsNullable: true},
+			{Name: "status", Type: "*string", ColumnType: "enum('a','b')", IsNullable: true},
+			{Name: "tags", Type: "*string", ColumnType: "set('x','y')", IsNullable: true},
+			{Name: "created_at", Type: "time.Time", ColumnType: "datetime"},
+			{Name: "paid_at", Type: "*time.Time", ColumnType: "datetime", IsNullable: true},
+		},
+		Indexes: []config.IndexInfo{{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true}},
+	}}
+}
+
+func TestGeneratedTypedFields(t *testing.T) {
+	main := `package main
+
+import (
+	"time"
+
+	"MODULE/orm"
+	"MODULE/orm/model"
+	"MODULE/orm/query"
+)
+
+func main() {
+	db := dryRun()
+	f := query.UsersFields
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// 每种字段类型的条件
+	query.NewUsersQuery(db).Where(f.ID.In(1, 2), f.Name.Like("a%"), f.Nickname.Eq("n"), f.Age.Gt(18), f.Hits.Between(1, 9)).Find()
+	query.NewUsersQuery(db).Where(f.Score.Lte(1.5), f.Active.Eq(true), f.Status.Neq(model.UsersStatusA), f.Tags.IsNull()).Find()
+	query.NewUsersQuery(db).Where(f.CreatedAt.Gte(day), f.PaidAt.IsNotNull(), f.Nickname.NotLike("x%")).Order(f.CreatedAt.Desc()).Find()
+	query.NewUsersQuery(db).SelectFields(f.ID, f.Tags).Order(f.ID.Asc()).Find()
+
+	// And、Or、Not 的嵌套
+	query.NewUsersQuery(db).Where(orm.And(orm.Or(f.ID.Eq(1), f.ID.Eq(2)), f.Name.Eq("a"))).Find()
+	query.NewUsersQuery(db).Where(orm.Or(orm.And(f.ID.Eq(1), f.Name.Eq("a")), orm.And(f.ID.Eq(2), f.Name.Eq("b")))).Find()
+	query.NewUsersQuery(db).Where(orm.Or(f.ID.Eq(1), f.ID.Eq(2)), f.Name.Eq("a")).Find()
+	query.NewUsersQuery(db).Where(orm.Not(orm.Or(f.ID.Eq(1), f.ID.Eq(2))), f.Name.Eq("a")).Find()
+	query.NewUsersQuery(db).Where(orm.Not(f.ID.In(1, 2), f.Name.Eq("a"))).Find()
+	query.NewUsersQuery(db).Where(orm.Not(f.ID.In(1, 2))).Find()
+	query.NewUsersQuery(db).Where(orm.Or(orm.Not(f.ID.Eq(1)), orm.And(f.Age.Gt(1), orm.Or(f.Age.Lt(0), f.Age.IsNull())))).Find()
+	query.NewUsersQuery(db).Where(f.ID.Eq(1)).Or(orm.And(f.ID.Eq(2), f.Name.Eq("b"))).Find()
+	query.NewUsersQuery(db).Not(orm.Or(f.ID.Eq(1), f.Name.Eq("a"))).Find()
+}
+`
+	want := []string{
+		"SELECT * FROM `users` WHERE `users`.`id` IN (1,2) AND `users`.`name` LIKE 'a%' AND `users`.`nickname` = 'n' AND `users`.`age` > 18 AND `users`.`hits` BETWEEN 1 AND 9",
+		"SELECT * FROM `users` WHERE `users`.`score` <= 1.5 AND `users`.`active` = true AND `users`.`status` <> 'a' AND `users`.`tags` IS NULL",
+		"SELECT * FROM `users` WHERE `users`.`created_at` >= '2024-01-02 00:00:00' AND `users`.`paid_at` IS NOT NULL AND `users`.`nickname` NOT LIKE 'x%' ORDER BY `users`.`created_at` DESC",
+		"SELECT `id`,`tags` FROM `users` ORDER BY `users`.`id`",
+		// And、Or 组合多个条件时整体加上括号
+		"SELECT * FROM `users` WHERE ((`users`.`id` = 1 OR `users`.`id` = 2) AND `users`.`name` = 'a')",
+		"SELECT * FROM `users` WHERE ((`users`.`id` = 1 AND `users`.`name` = 'a') OR (`users`.`id` = 2 AND `users`.`name` = 'b'))",
+		"SELECT * FROM `users` WHERE (`users`.`id` = 1 OR `users`.`id` = 2) AND `users`.`name` = 'a'",
+		"SELECT * FROM `users` WHERE NOT (`users`.`id` = 1 OR `users`.`id` = 2) AND `users`.`name` = 'a'",
+		"SELECT * FROM `users` WHERE NOT (`users`.`id` IN (1,2) AND `users`.`name` = 'a')",
+		"SELECT * FROM `users` WHERE `users`.`id` NOT IN (1,2)",
+		"SELECT * FROM `users` WHERE (`users`.`id` <> 1 OR (`users`.`age` > 1 AND (`users`.`age` < 0 OR `users`.`age` IS NULL)))",
+		"SELECT * FROM `users` WHERE `users`.`id` = 1 OR (`users`.`id` = 2 AND `users`.`name` = 'b')",
+		"SELECT * FROM `users` WHERE NOT (`users`.`id` = 1 OR `users`.`name` = 'a')",
+	}
+
+	for _, nullable := range []string{"pointer", "sql_null", "generic"} {
+		t.Run(nullable, func(t *testing.T) {
+			output := runGenerated(t, typedFieldTables(), &config.Config{Nullable: nullable}, main)
+			lines := strings.Split(strings.TrimSpace(output), "\n")
+			if len(lines) != len(want) {
+				t.Fatalf("输出了 %d 条 SQL，期望 %d 条:\n%s", len(lines), len(want), output)
+			}
+			for i := range want {
+				if lines[i] != want[i] {
+					t.Errorf("第 %d 条 SQL:\n得到 %s\n期望 %s", i+1, lines[i], want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// fakeProvider 返回固定表结构的表结构提供者
+type fakeProvider struct {
+	tables []*config.TableInfo
+}
+
+func (p *fakeProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	return p.tables, nil
+}
+
+func fakeTables() []*config.TableInfo {
+	return []*config.TableInfo{
+		{
+			Name:    "users",
+			Comment: "用户",
+			Fields: []config.FieldInfo{
+				{Name: "id", Type: "uint64", ColumnType: "bigint unsigned", IsPrimary: true},
+				{Name: "email", Type: "string", ColumnType: "varchar(64)"},
+				{Name: "nickname", Type: "*string", ColumnType: "varchar(32)", IsNullable: true},
+				{Name: "created_at", Type: "time.Time", ColumnType: "datetime"},
+			},
+			Indexes: []config.IndexInfo{
+				{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+				{Name: "uk_email", Fields: []string{"email"}, IsUniq: true},
+			},
+		},
+		{
+			Name:    "posts",
+			Comment: "文章",
+			Fields: []config.FieldInfo{
+				{Name: "id", Type: "uint64", ColumnType: "bigint unsigned", IsPrimary: true},
+				{Name: "user_id", Type: "uint64", ColumnType: "bigint unsigned"},
+				{Name: "title", Type: "string", ColumnType: "varchar(128)"},
+			},
+			Indexes: []config.IndexInfo{
+				{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+				{Name: "idx_user_id", Fields: []string{"user_id"}},
+			},
+			ForeignKeys: []config.ForeignKeyInfo{
+				{Name: "fk_posts_user", Fields: []string{"user_id"}, RefTable: "users", RefFields: []string{"id"}},
+			},
+		},
+	}
+}
+
+// dryRunSource 生成代码测试程序中使用的 dryRun 函数，返回不连接数据库的 MySQL 连接，执行的 SQL 会带参数输出到标准输出
+const dryRunSource = `package main
+
+import (
+	"fmt"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func dryRun() *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "root@tcp(127.0.0.1:3306)/test", SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		panic(err)
+	}
+	print := func(db *gorm.DB) {
+		fmt.Println(db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+	}
+	db.Callback().Query().After("gorm:query").Register("test:print", print)
+	db.Callback().Update().After("gorm:update").Register("test:print", print)
+	db.Callback().Delete().After("gorm:delete").Register("test:print", print)
+	return db
+}
+`
+
+// runGenerated 将 tables 生成到 testdata 下的临时目录，与测试程序 main 一起编译运行并返回输出。
+// main 中的 MODULE 会替换为生成代码的模块路径，可以导入 MODULE/orm、MODULE/orm/model、MODULE/orm/query，
+// 并通过 dryRun() 获取输出 SQL 的连接
+func runGenerated(t *testing.T, tables []*config.TableInfo, cfg *config.Config, main string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("short 模式下跳过编译生成代码的测试")
+	}
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := os.MkdirTemp("testdata", "gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Remove("testdata")
+	})
+
+	module := "github.com/tokmz/zero/cmd/" + filepath.ToSlash(dir)
+	cfg.ModuleName = module
+	cfg.Output = config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model", QueryDir: "orm/query"}
+	tables, err = LoadTables(context.Background(), &fakeProvider{tables: tables}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	out := newMemoryOutput()
+	if err := GenerateFiles(tables, cfg, out); err != nil {
+		t.Fatalf("GenerateFiles: %v", err)
+	}
+	out.files["main/main.go"] = []byte(strings.ReplaceAll(main, "MODULE", module))
+	out.files["main/dryrun.go"] = []byte(dryRunSource)
+	for path, content := range out.files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("go", "run", "./"+filepath.ToSlash(filepath.Join(dir, "main")))
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=readonly")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("运行生成的代码失败: %v\n%s", err, output)
+	}
+	return string(output)
+}
+
+func TestGenerateFilesWithFakeProvider(t *testing.T) {
+	cfg := &config.Config{
+		ModuleName: "example.com/app",
+		Style:      "snake",
+		Output: config.OutputConfig{
+			OrmDir:   "orm",
+			ModelDir: "orm/model",
+			QueryDir: "orm/query",
+		},
+	}
+
+	tables, err := LoadTables(context.Background(), &fakeProvider{tables: fakeTables()}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	out := newMemoryOutput()
+	if err := GenerateFiles(tables, cfg, out); err != nil {
+		t.Fatalf("GenerateFiles: %v", err)
+	}
+
+	want := map[string][]string{
+		"orm/orm.go":         {"package orm", "func EncodeCursor("},
+		"orm/model/users.go": {"package model", "type Users struct", "func (m *Users) TableName() string"},
+		"orm/model/posts.go": {"type Posts struct", "User *Users"},
+		"orm/query/users.go": {"package query", "func (q *UsersQuery) FindByEmail(ctx context.Context, email string)", "func (q *UsersQuery) DeleteByID(", "columns ...UsersColumn) error", `[]clause.Column{{Name: "id"}}`},
+		"orm/query/posts.go": {"func (q *PostsQuery) ListByUserID(ctx context.Context, userID uint64)"},
+	}
+	if len(out.paths) != len(want) {
+		t.Errorf("生成了 %d 个文件 %v，期望 %d 个", len(out.paths), out.paths, len(want))
+	}
+	for path, snippets := range want {
+		content, ok := out.files[filepath.Clean(path)]
+		if !ok {
+			t.Errorf("没有生成 %s", path)
+			continue
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), path, content, parser.AllErrors); err != nil {
+			t.Errorf("%s 不是合法的 Go 代码: %v", path, err)
+		}
+		for _, snippet := range snippets {
+			if !strings.Contains(string(content), snippet) {
+				t.Errorf("%s 中缺少 %q", path, snippet)
+			}
+		}
+	}
+}
+
+func TestNewSchemaProviderUsesRegisteredProvider(t *testing.T) {
+	provider := &fakeProvider{tables: fakeTables()}
+	RegisterProvider("fake", func(cfg *config.Config) (SchemaProvider, error) {
+		return provider, nil
+	})
+	defer func() {
+		providersMu.Lock()
+		delete(providers, "fake")
+		providersMu.Unlock()
+	}()
+
+	got, err := NewSchemaProvider(&config.Config{Driver: "fake"})
+	if err != nil {
+		t.Fatalf("NewSchemaProvider: %v", err)
+	}
+	if got != provider {
+		t.Errorf("NewSchemaProvider 返回了 %T，期望注册的 fakeProvider", got)
+	}
+}
+
+func TestRelationNameAvoidsRenamedField(t *testing.T) {
+	cfg := &config.Config{
+		Naming: config.NamingConfig{Columns: map[string]string{"posts.title": "User"}},
+	}
+	tables, err := LoadTables(context.Background(), &fakeProvider{tables: fakeTables()}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	if err := applyNames(tables, cfg); err != nil {
+		t.Fatalf("applyNames: %v", err)
+	}
+	posts := tables[1]
+	if len(posts.Relations) != 1 || posts.Relations[0].GoName != "Users" {
+		t.Errorf("posts 的关联为 %+v，期望字段名为 Users", posts.Relations)
+	}
+
+	cfg.Relations = map[string][]config.Relation{
+		"posts": {{Target: "users", Type: "belongs_to", ForeignKey: "user_id", References: "id"}},
+	}
+	cfg.Naming.Columns["posts.title"] = "Users"
+	tables, err = LoadTables(context.Background(), &fakeProvider{tables: fakeTables()}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	if err := applyNames(tables, cfg); err == nil || !strings.Contains(err.Error(), "关联 users") {
+		t.Errorf("applyNames 返回 %v，期望关联与字段重名的错误", err)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"go/build"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+)
+
+/*
+   @NAME    : json
+   @author  : 清风
+   @desc    : JSON 列绑定 Go 类型（json 配置），字段类型为 orm 包中生成的 JSON[T]
+   @time    : 2026/10/17
+*/
+
+// jsonRule JSON 列绑定的类型
+type jsonRule struct {
+	goType  string   // 字段类型，如 orm.JSON[settings.UserSettings]
+	imports []string // 需要导入的包：orm 包及 T 所在的包
+}
+
+// buildJSONRules 解析 json 配置，键为小写的 表名.列名。
+// 类型可以写完整导入路径（github.com/foo/settings.UserSettings），
+// 也可以写相对于 module_name 的路径（pkg/settings.UserSettings）
+func buildJSONRules(cfg *config.Config) (map[string]jsonRule, error) {
+	rules := make(map[string]jsonRule, len(cfg.JSON))
+	if len(cfg.JSON) == 0 {
+		return rules, nil
+	}
+	if cfg.ModuleName == "" {
+		return nil, fmt.Errorf("使用 json 配置需要设置 module_name")
+	}
+	if filepath.Clean(cfg.Output.ModelDir) == filepath.Clean(cfg.Output.OrmDir) {
+		return nil, fmt.Errorf("使用 json 配置时 model 目录不能与 orm 目录相同")
+	}
+
+	ormPath, ormPackage := ormImport(cfg)
+	for key, spec := range cfg.JSON {
+		goType, importPath, err := utils.ParseGoType(spec)
+		if err != nil {
+			return nil, fmt.Errorf("JSON 列 %s 的类型配置错误: %v", key, err)
+		}
+		if isModulePath(importPath) {
+			importPath = path.Join(cfg.ModuleName, importPath)
+		}
+		rules[strings.ToLower(key)] = jsonRule{
+			goType:  ormPackage + ".JSON[" + goType + "]",
+			imports: compactImports([]string{ormPath, importPath}),
+		}
+	}
+	return rules, nil
+}
+
+// ormImport 返回 orm 包的导入路径和包名
+func ormImport(cfg *config.Config) (importPath, packageName string) {
+	dir := filepath.ToSlash(filepath.Clean(cfg.Output.OrmDir))
+	return path.Join(cfg.ModuleName, dir), dirPackage(cfg.Output.OrmDir, "orm")
+}
+
+// isModulePath 判断导入路径是否为相对于当前模块的路径：第一段不含点号且不是标准库
+func isModulePath(importPath string) bool {
+	if importPath == "" || strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		return false
+	}
+	pkg, err := build.Default.Import(importPath, "", build.FindOnly)
+	return err != nil || !pkg.Goroot
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestBuildJSONRules(t *testing.T) {
+	cfg := &config.Config{
+		ModuleName: "example.com/app",
+		Output:     config.OutputConfig{OrmDir: "internal/db", ModelDir: "internal/db/model"},
+		JSON: map[string]string{
+			"Users.Settings": "pkg/settings.UserSettings",
+			"users.profile":  "*github.com/foo/profile.Profile",
+			"users.links":    "net/url.Values",
+			"users.tags":     "[]string",
+		},
+	}
+	rules, err := buildJSONRules(cfg)
+	if err != nil {
+		t.Fatalf("buildJSONRules: %v", err)
+	}
+	want := map[string]jsonRule{
+		// 相对于 module_name 的路径
+		"users.settings": {goType: "db.JSON[settings.UserSettings]", imports: []string{"example.com/app/internal/db", "example.com/app/pkg/settings"}},
+		"users.profile":  {goType: "db.JSON[*profile.Profile]", imports: []string{"example.com/app/internal/db", "github.com/foo/profile"}},
+		// 标准库不是相对路径
+		"users.links": {goType: "db.JSON[url.Values]", imports: []string{"example.com/app/internal/db", "net/url"}},
+		"users.tags":  {goType: "db.JSON[[]string]", imports: []string{"example.com/app/internal/db"}},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("得到 %+v\n期望 %+v", rules, want)
+	}
+}
+
+func TestBuildJSONRulesErrors(t *testing.T) {
+	json := map[string]string{"users.settings": "pkg/settings.UserSettings"}
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{
+			name: "缺少 module_name",
+			cfg:  config.Config{JSON: json, Output: config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model"}},
+			want: "使用 json 配置需要设置 module_name",
+		},
+		{
+			name: "model 目录与 orm 目录相同",
+			cfg:  config.Config{JSON: json, ModuleName: "example.com/app", Output: config.OutputConfig{OrmDir: "./orm", ModelDir: "orm/"}},
+			want: "使用 json 配置时 model 目录不能与 orm 目录相同",
+		},
+		{
+			name: "类型无效",
+			cfg:  config.Config{JSON: map[string]string{"users.settings": "map[string]any"}, ModuleName: "example.com/app", Output: config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model"}},
+			want: "JSON 列 users.settings 的类型配置错误",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildJSONRules(&tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("buildJSONRules 返回 %v，期望包含 %q", err, tt.want)
+			}
+		})
+	}
+
+	// 没有 json 配置时不检查 module_name 和目录
+	if rules, err := buildJSONRules(&config.Config{}); err != nil || len(rules) != 0 {
+		t.Errorf("没有 json 配置时 buildJSONRules 返回 %v, %v", rules, err)
+	}
+}
+
+func TestGeneratedJSONScan(t *testing.T) {
+	tables := []*config.TableInfo{{
+		Name: "users",
+		Fields: []config.FieldInfo{
+			{Name: "id", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+			{Name: "links", Type: "string", ColumnType: "json"},
+			{Name: "extra", Type: "*string", ColumnType: "json", IsNullable: true},
+		},
+		Indexes: []config.IndexInfo{{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true}},
+	}}
+	cfg := &config.Config{JSON: map[string]string{"users.links": "net/url.Values", "users.extra": "net/url.Values"}}
+	output := runGenerated(t, tables, cfg, `package main
+
+import (
+	"fmt"
+	"net/url"
+
+	"MODULE/orm"
+	"MODULE/orm/model"
+)
+
+func main() {
+	user := model.Users{Links: orm.NewJSON(url.Values{"a": {"1"}})}
+	value, err := user.Links.Value()
+	fmt.Printf("value %v %v %v\n", value, err, user.Extra == nil)
+
+	var links orm.JSON[url.Values]
+	err = links.Scan([]byte("{\"b\":[\"2\"]}"))
+	fmt.Println("bytes", links.Data.Get("b"), err)
+	err = links.Scan("{\"c\":[\"3\"]}")
+	fmt.Println("string", links.Data.Get("c"), links.Data.Has("b"), err)
+	err = links.Scan(nil)
+	fmt.Println("nil", links.Data == nil, err)
+	fmt.Println("invalid", links.Scan("{"))
+	fmt.Println("type", links.Scan(1))
+}
+`)
+	want := []string{
+		`value {"a":["1"]} <nil> true`,
+		"bytes 2 <nil>",
+		"string 3 false <nil>",
+		"nil true <nil>",
+		"invalid 解码 JSON 列失败: unexpected end of JSON input",
+		"type 无法将 int 转换为 JSON",
+	}
+	for _, line := range want {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("输出中缺少 %q，输出:\n%s", line, output)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/naming"
+)
+
+/*
+   @NAME    : naming
+   @author  : 清风
+   @desc    : 表、字段对应的 Go 名称与文件名
+   @time    : 2026/10/17
+*/
+
+// modelMethods 模型上生成的方法，字段名不能与之相同
+var modelMethods = map[string]bool{
+	"TableName":    true,
+	"BeforeCreate": true,
+	"BeforeUpdate": true,
+}
+
+// applyNames 确定每个表的模型类型名、文件名以及字段和关联的 Go 名称。
+// 模型名优先使用 naming.tables 中指定的名称，否则去掉配置的表名前缀、后缀后按命名规则转换，
+// TableName() 仍返回真实的表名。字段名优先使用 naming.columns 中指定的名称。
+// 转换结果不是合法的导出标识符（如以数字开头、中文名称）或与模型方法重名时会被调整，调整的名称会打印出来。
+// 多个表得到相同的模型名，同一个表中多个字段、关联得到相同的字段名，或列名、列类型包含反引号时返回错误
+func applyNames(tables []*config.TableInfo, cfg *config.Config) error {
+	namer := newNamer(cfg)
+	tableRenames := lowerKeys(cfg.Naming.Tables)
+	columnRenames := lowerKeys(cfg.Naming.Columns)
+	var adjusted []string
+
+	owners := make(map[string][]string, len(tables))
+	models := make(map[string]string, len(tables))
+	for _, table := range tables {
+		if name, ok := tableRenames[strings.ToLower(table.Name)]; ok && !isExportedIdent(name) {
+			return fmt.Errorf("naming.tables 中表 %s 的模型名 %s 不是有效的导出标识符", table.Name, name)
+		}
+		table.BaseName = trimTableName(table.Name, cfg)
+		table.ModelName = modelName(table.Name, cfg, namer, tableRenames)
+		if name := namer.Pascal(table.BaseName); !hasRename(tableRenames, table.Name) && name != table.ModelName {
+			adjusted = append(adjusted, fmt.Sprintf("表 %s: %s -> %s", table.Name, name, table.ModelName))
+		}
+		owners[table.ModelName] = append(owners[table.ModelName], table.Name)
+		models[table.Name] = table.ModelName
+
+		fields := make(map[string]string, len(table.Fields))
+		for i := range table.Fields {
+			field := &table.Fields[i]
+			// 结构体标签写在反引号中，无法包含反引号
+			if strings.Contains(field.Name+field.ColumnType, "`") {
+				return fmt.Errorf("表 %s 的列 %s（%s）包含反引号，无法生成结构体标签", table.Name, field.Name, field.ColumnType)
+			}
+			key := strings.ToLower(table.Name + "." + field.Name)
+			if name, ok := columnRenames[key]; ok && !isExportedIdent(name) {
+				return fmt.Errorf("naming.columns 中列 %s.%s 的字段名 %s 不是有效的导出标识符", table.Name, field.Name, name)
+			}
+			field.GoName = columnGoName(table.Name, field.Name, namer, columnRenames)
+			if name := namer.Pascal(field.Name); !hasRename(columnRenames, key) && name != field.GoName {
+				adjusted = append(adjusted, fmt.Sprintf("列 %s.%s: %s -> %s", table.Name, field.Name, name, field.GoName))
+			}
+			if other, ok := fields[field.GoName]; ok {
+				return fmt.Errorf("表 %s 的列 %s 与 %s 生成的字段名都是 %s，请在 naming.columns 中指定字段名", table.Name, other, field.Name, field.GoName)
+			}
+			fields[field.GoName] = field.Name
+		}
+	}
+
+	var conflicts []string
+	for name, owner := range owners {
+		if len(owner) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s（%s）", name, strings.Join(owner, ", ")))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("以下表生成的模型名重复，请调整 prefix/suffix 配置或在 naming.tables 中指定模型名: %s", strings.Join(conflicts, "; "))
+	}
+
+	// 关联字段与模型中的其他字段在同一个结构体中，字段名不能重复
+	for _, table := range tables {
+		names := make(map[string]string, len(table.Fields)+len(table.Relations))
+		for _, field := range table.Fields {
+			names[field.GoName] = "列 " + field.Name
+		}
+		for i := range table.Relations {
+			rel := &table.Relations[i]
+			rel.GoName = relationGoName(rel.Name, namer)
+			if other, ok := names[rel.GoName]; ok {
+				return fmt.Errorf("表 %s 的关联 %s 与%s 生成的字段名都是 %s，请调整 relations 配置或在 naming.columns 中指定字段名", table.Name, rel.Name, other, rel.GoName)
+			}
+			names[rel.GoName] = "关联 " + rel.Name
+			if name, ok := models[rel.Model]; ok {
+				rel.ModelName = name
+			} else {
+				rel.ModelName = modelName(rel.Model, cfg, namer, tableRenames)
+			}
+		}
+	}
+
+	for _, dir := range []string{cfg.Output.OrmDir, cfg.Output.ModelDir, cfg.Output.QueryDir} {
+		if base := filepath.Base(dir); base != dirPackage(dir, base) {
+			adjusted = append(adjusted, fmt.Sprintf("目录 %s 的包名: %s -> %s", dir, base, dirPackage(dir, base)))
+		}
+	}
+
+	if len(adjusted) > 0 {
+		fmt.Println("\n以下名称不是合法的 Go 标识符，已自动调整（可在 naming 配置中指定名称）:")
+		for _, item := range adjusted {
+			fmt.Printf("  - %s\n", item)
+		}
+	}
+	return nil
+}
+
+// newNamer 按配置的命名词典创建 Namer
+func newNamer(cfg *config.Config) *naming.Namer {
+	return naming.New(cfg.Naming.Dictionary)
+}
+
+// columnGoName 返回列对应的字段名：优先使用 naming.columns 中指定的名称，否则转换为合法的导出标识符，与模型方法重名时加 _ 后缀
+func columnGoName(table, column string, namer *naming.Namer, renames map[string]string) string {
+	name, ok := renames[strings.ToLower(table+"."+column)]
+	if !ok {
+		name = naming.Exported(namer.Pascal(column))
+	}
+	if modelMethods[name] {
+		name += "_"
+	}
+	return name
+}
+
+// relationGoName 返回关联名对应的字段名，与模型方法重名时加 _ 后缀
+func relationGoName(name string, namer *naming.Namer) string {
+	name = naming.Exported(namer.Pascal(name))
+	if modelMethods[name] {
+		name += "_"
+	}
+	return name
+}
+
+// modelName 返回表对应的模型名，未在 naming.tables 中指定时转换为合法的导出标识符
+func modelName(table string, cfg *config.Config, namer *naming.Namer, renames map[string]string) string {
+	if name, ok := renames[strings.ToLower(table)]; ok {
+		return name
+	}
+	return naming.Exported(namer.Pascal(trimTableName(table, cfg)))
+}
+
+// hasRename 判断 renames 中是否指定了名称
+func hasRename(renames map[string]string, key string) bool {
+	_, ok := renames[strings.ToLower(key)]
+	return ok
+}
+
+// dirPackage 根据输出目录推断包名并转换为合法的包名，目录为当前目录等无法推断时使用 fallback
+func dirPackage(dir, fallback string) string {
+	base := filepath.Base(filepath.Clean(dir))
+	if base == "." || base == string(filepath.Separator) {
+		return fallback
+	}
+	return naming.PackageName(base)
+}
+
+// isExportedIdent 判断名称是否为导出的 Go 标识符
+func isExportedIdent(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
+
+// lowerKeys 返回键转换为小写后的 map
+func lowerKeys(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for key, value := range m {
+		result[strings.ToLower(key)] = value
+	}
+	return result
+}
+
+// trimTableName 去掉表名中最长的匹配前缀和后缀（不区分大小写）。去掉后为空或只剩下划线的前缀、后缀不会使用，
+// 如前缀为 t_、t_user_ 时表 t_user_ 去掉 t_ 得到 user_；没有可用的前缀、后缀时保留原表名
+func trimTableName(name string, cfg *config.Config) string {
+	name = trimAffix(name, cfg.Prefix, func(name, prefix string) (string, bool) {
+		if len(name) < len(prefix) || !strings.EqualFold(name[:len(prefix)], prefix) {
+			return "", false
+		}
+		return name[len(prefix):], true
+	})
+	return trimAffix(name, cfg.Suffix, func(name, suffix string) (string, bool) {
+		if len(name) < len(suffix) || !strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+			return "", false
+		}
+		return name[:len(name)-len(suffix)], true
+	})
+}
+
+// trimAffix 用 trim 去掉 affixes 中与 name 匹配的最长的前缀或后缀，去掉后为空或只剩下划线的不使用
+func trimAffix(name string, affixes []string, trim func(name, affix string) (string, bool)) string {
+	result, longest := name, 0
+	for _, affix := range affixes {
+		if len(affix) <= longest {
+			continue
+		}
+		if rest, ok := trim(name, affix); ok && strings.Trim(rest, "_") != "" {
+			result, longest = rest, len(affix)
+		}
+	}
+	return result
+}
+
+// tableFileName 按命名风格生成表对应的文件名（不含前缀、后缀）：
+//   - snake: user_order.go
+//   - camel: userOrder.go
+//   - pascal: UserOrder.go
+//   - kebab: user-order.go
+//   - lower: userorder.go
+func tableFileName(table *config.TableInfo, cfg *config.Config) string {
+	var name string
+	switch cfg.Style {
+	case "snake":
+		name = naming.Snake(table.BaseName)
+	case "camel":
+		name = newNamer(cfg).Camel(table.BaseName)
+	case "pascal":
+		name = newNamer(cfg).Pascal(table.BaseName)
+	case "kebab":
+		name = naming.Kebab(table.BaseName)
+	case "lower":
+		name = naming.Lower(table.BaseName)
+	default:
+		name = table.BaseName
+	}
+	return name + ".go"
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestTrimTableName(t *testing.T) {
+	cfg := &config.Config{
+		Prefix: []string{"t_", "t_user_", "T_SYS_"},
+		Suffix: []string{"_tab", "_log_tab"},
+	}
+	tests := map[string]string{
+		"t_order": "order",
+		"users":   "users",
+		// 多个前缀匹配时去掉最长的，不区分大小写
+		"t_user_address":  "address",
+		"t_sys_config":    "config",
+		"T_Sys_Config":    "Config",
+		"t_order_tab":     "order",
+		"t_order_log_tab": "order",
+		// 去掉最长的前缀后为空时使用较短的前缀
+		"t_user_": "user_",
+		"t_sys_":  "sys_",
+		"t_user":  "user",
+		// 表名与前缀、后缀相同时保留原表名
+		"t_":   "t_",
+		"_tab": "_tab",
+		// 去掉前缀后再去掉后缀为空时不去掉后缀
+		"t__tab": "_tab",
+	}
+	for name, want := range tests {
+		if got := trimTableName(name, cfg); got != want {
+			t.Errorf("trimTableName(%q) = %q，期望 %q", name, got, want)
+		}
+	}
+}
+
+func TestApplyNamesPrefixConflict(t *testing.T) {
+	tables := func(names ...string) []*config.TableInfo {
+		var tables []*config.TableInfo
+		for _, name := range names {
+			tables = append(tables, &config.TableInfo{
+				Name:   name,
+				Fields: []config.FieldInfo{{Name: "id", Type: "int64", ColumnType: "bigint", IsPrimary: true}},
+			})
+		}
+		return tables
+	}
+	cfg := &config.Config{Prefix: []string{"t_", "tb_"}, Suffix: []string{"_info"}, Style: "snake"}
+
+	ok := tables("t_user_order", "tb_user_info", "users")
+	if err := applyNames(ok, cfg); err != nil {
+		t.Fatalf("applyNames: %v", err)
+	}
+	for i, want := range []string{"UserOrder", "User", "Users"} {
+		if ok[i].ModelName != want {
+			t.Errorf("表 %s 的模型名为 %s，期望 %s", ok[i].Name, ok[i].ModelName, want)
+		}
+	}
+
+	// 去掉前缀、后缀后重名的表都会列出
+	err := applyNames(tables("t_user", "tb_user_info", "user", "t_order", "tb_order", "posts"), cfg)
+	want := "以下表生成的模型名重复，请调整 prefix/suffix 配置"
+	if err == nil || !strings.Contains(err.Error(), want) ||
+		!strings.Contains(err.Error(), "Order（t_order, tb_order）; User（t_user, tb_user_info, user）") {
+		t.Errorf("applyNames 返回 %v，期望列出重名的表", err)
+	}
+}
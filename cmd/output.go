@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+/*
+   @NAME    : output
+   @author  : 清风
+   @desc    : 生成文件的输出目标（磁盘、内存）
+   @time    : 2026/10/17
+*/
+
+// generatedHeader 生成文件的头部注释，用于识别由 zero 生成的文件
+const generatedHeader = "// Code generated by github.com/tokmz/zero. DO NOT EDIT."
+
+// Output 生成文件的输出目标，生成器通过它写入格式化后的代码
+type Output interface {
+	// WriteFile 写入生成的文件，path 为相对于当前工作目录的路径
+	WriteFile(path string, content []byte) error
+}
+
+// diskOutput 直接写入磁盘
+type diskOutput struct{}
+
+// WriteFile 创建目录并写入文件
+func (diskOutput) WriteFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("写入文件失败: %v", err)
+	}
+
+	fmt.Printf("  生成文件: %s\n", path)
+	return nil
+}
+
+// memoryOutput 将生成的文件保存在内存中，按写入顺序记录路径
+type memoryOutput struct {
+	paths []string
+	files map[string][]byte
+}
+
+// newMemoryOutput 创建内存输出
+func newMemoryOutput() *memoryOutput {
+	return &memoryOutput{files: make(map[string][]byte)}
+}
+
+// WriteFile 保存文件内容，重复写入同一路径时覆盖之前的内容
+func (o *memoryOutput) WriteFile(path string, content []byte) error {
+	path = filepath.Clean(path)
+	if _, ok := o.files[path]; !ok {
+		o.paths = append(o.paths, path)
+	}
+	o.files[path] = content
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+/*
+   @NAME    : region
+   @author  : 清风
+   @desc    : 生成文件中的保护区域，重新生成时保留区域内手写的代码
+   @time    : 2026/10/17
+*/
+
+// regionMarker 保护区域的起止标记，如 // zero:begin custom 与 // zero:end custom
+var regionMarker = regexp.MustCompile(`^\s*// zero:(begin|end) (\S+)\s*$`)
+
+// region 保护区域，begin、end 为起止标记所在的行号
+type region struct {
+	name  string
+	begin int
+	end   int
+}
+
+// findRegions 按出现顺序查找文本中的保护区域，不允许嵌套、重名或缺少结束标记
+func findRegions(lines []string) ([]region, error) {
+	var regions []region
+	seen := make(map[string]bool)
+	current := -1
+	for i, line := range lines {
+		match := regionMarker.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		kind, name := match[1], match[2]
+
+		if kind == "begin" {
+			if current >= 0 {
+				return nil, fmt.Errorf("第 %d 行: 保护区域 %s 未结束，不能嵌套 %s", i+1, regions[current].name, name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("第 %d 行: 保护区域 %s 重复", i+1, name)
+			}
+			seen[name] = true
+			regions = append(regions, region{name: name, begin: i, end: -1})
+			current = len(regions) - 1
+			continue
+		}
+
+		if current < 0 || regions[current].name != name {
+			return nil, fmt.Errorf("第 %d 行: 保护区域 %s 的结束标记没有对应的开始标记", i+1, name)
+		}
+		regions[current].end = i
+		current = -1
+	}
+	if current >= 0 {
+		return nil, fmt.Errorf("保护区域 %s 缺少结束标记 // zero:end %s", regions[current].name, regions[current].name)
+	}
+	return regions, nil
+}
+
+// preserveRegions 将磁盘上已有文件 path 中保护区域的内容填回新生成的代码 generated。
+// 文件不存在时原样返回；已有文件中非空的区域在新代码中找不到同名区域时输出警告，该区域的内容不会保留
+func preserveRegions(path string, generated []byte) ([]byte, error) {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return generated, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取文件 %s 失败: %v", path, err)
+	}
+
+	// 生成的代码统一使用 LF 换行，已有文件可能被编辑器或 git 转换为 CRLF
+	oldLines := strings.SplitAfter(strings.ReplaceAll(string(existing), "\r\n", "\n"), "\n")
+	oldRegions, err := findRegions(oldLines)
+	if err != nil {
+		// 标记损坏时不能覆盖文件，否则手写的代码会丢失
+		return nil, fmt.Errorf("解析文件 %s 的保护区域失败: %v", path, err)
+	}
+	if len(oldRegions) == 0 {
+		return generated, nil
+	}
+
+	newLines := strings.SplitAfter(string(generated), "\n")
+	newRegions, err := findRegions(newLines)
+	if err != nil {
+		return nil, fmt.Errorf("解析模板生成的保护区域失败: %v", err)
+	}
+	placed := make(map[string]region, len(newRegions))
+	for _, r := range newRegions {
+		placed[r.name] = r
+	}
+
+	contents := make(map[string][]string, len(oldRegions))
+	for _, r := range oldRegions {
+		content := oldLines[r.begin+1 : r.end]
+		if strings.TrimSpace(strings.Join(content, "")) == "" {
+			continue
+		}
+		if _, ok := placed[r.name]; !ok {
+			fmt.Printf("  警告: 文件 %s 中的保护区域 %s 在新生成的代码中不存在，其中的 %d 行代码不会保留\n",
+				path, r.name, len(content))
+			continue
+		}
+		contents[r.name] = content
+	}
+
+	var buf strings.Builder
+	last := 0
+	for _, r := range newRegions {
+		content, ok := contents[r.name]
+		if !ok {
+			continue
+		}
+		buf.WriteString(strings.Join(newLines[last:r.begin+1], ""))
+		buf.WriteString(strings.Join(content, ""))
+		last = r.end
+	}
+	buf.WriteString(strings.Join(newLines[last:], ""))
+	return []byte(buf.String()), nil
+}
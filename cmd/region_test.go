@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRegionFile 将 content 写入临时目录中的文件并返回其路径
+func writeRegionFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "users.go")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindRegions(t *testing.T) {
+	lines := strings.SplitAfter("package a\n// zero:begin imports\n\n  // zero:end imports  \n// zero:begin custom\r\nfunc f() {}\r\n// zero:end custom\r\n", "\n")
+	regions, err := findRegions(lines)
+	if err != nil {
+		t.Fatalf("findRegions: %v", err)
+	}
+	want := []region{{name: "imports", begin: 1, end: 3}, {name: "custom", begin: 4, end: 6}}
+	if len(regions) != len(want) {
+		t.Fatalf("得到 %+v，期望 %+v", regions, want)
+	}
+	for i := range want {
+		if regions[i] != want[i] {
+			t.Errorf("第 %d 个区域为 %+v，期望 %+v", i, regions[i], want[i])
+		}
+	}
+}
+
+func TestFindRegionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"缺少结束标记", "// zero:begin custom\nfunc f() {}\n", "保护区域 custom 缺少结束标记"},
+		{"缺少开始标记", "func f() {}\n// zero:end custom\n", "第 2 行: 保护区域 custom 的结束标记没有对应的开始标记"},
+		{"结束标记不匹配", "// zero:begin a\n// zero:end b\n", "第 2 行: 保护区域 b 的结束标记没有对应的开始标记"},
+		{"嵌套", "// zero:begin a\n// zero:begin b\n// zero:end b\n// zero:end a\n", "第 2 行: 保护区域 a 未结束，不能嵌套 b"},
+		{"重复的开始标记", "// zero:begin a\n// zero:end a\n// zero:begin a\n// zero:end a\n", "第 3 行: 保护区域 a 重复"},
+		{"重复的结束标记", "// zero:begin a\n// zero:end a\n// zero:end a\n", "第 3 行: 保护区域 a 的结束标记没有对应的开始标记"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := findRegions(strings.SplitAfter(tt.content, "\n"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("findRegions 返回 %v，期望包含 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreserveRegions(t *testing.T) {
+	generated := "package a\n\nimport (\n\t// zero:begin imports\n\t// zero:end imports\n)\n\nfunc g() {}\n\n// zero:begin custom\n// zero:end custom\n"
+
+	tests := []struct {
+		name     string
+		existing string // 为空表示文件不存在
+		want     string
+		warning  string
+	}{
+		{
+			name: "文件不存在",
+			want: generated,
+		},
+		{
+			name:     "重新生成时保留区域内容",
+			existing: "package a\n\nimport (\n\t// zero:begin imports\n\t\"fmt\"\n\t// zero:end imports\n)\n\nfunc old() {}\n\n// zero:begin custom\nfunc f() { fmt.Println() }\n// zero:end custom\n",
+			want:     "package a\n\nimport (\n\t// zero:begin imports\n\t\"fmt\"\n\t// zero:end imports\n)\n\nfunc g() {}\n\n// zero:begin custom\nfunc f() { fmt.Println() }\n// zero:end custom\n",
+		},
+		{
+			name:     "CRLF 换行的已有文件",
+			existing: "package a\r\n\r\n// zero:begin custom\r\nfunc f() {}\r\n\r\nfunc h() {}\r\n// zero:end custom\r\n",
+			want:     "package a\n\nimport (\n\t// zero:begin imports\n\t// zero:end imports\n)\n\nfunc g() {}\n\n// zero:begin custom\nfunc f() {}\n\nfunc h() {}\n// zero:end custom\n",
+		},
+		{
+			name:     "模板中已删除的区域",
+			existing: "package a\n\n// zero:begin removed\nfunc f() {}\n// zero:end removed\n\n// zero:begin custom\nfunc h() {}\n// zero:end custom\n",
+			want:     "package a\n\nimport (\n\t// zero:begin imports\n\t// zero:end imports\n)\n\nfunc g() {}\n\n// zero:begin custom\nfunc h() {}\n// zero:end custom\n",
+			warning:  "保护区域 removed 在新生成的代码中不存在，其中的 1 行代码不会保留",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "users.go")
+			if tt.existing != "" {
+				path = writeRegionFile(t, tt.existing)
+			}
+			var got []byte
+			var err error
+			output := captureStdout(t, func() { got, err = preserveRegions(path, []byte(generated)) })
+			if err != nil {
+				t.Fatalf("preserveRegions: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("得到:\n%s\n期望:\n%s", got, tt.want)
+			}
+			if (tt.warning == "" && output != "") || !strings.Contains(output, tt.warning) {
+				t.Errorf("输出 %q，期望包含 %q", output, tt.warning)
+			}
+		})
+	}
+}
+
+func TestPreserveRegionsBrokenMarkers(t *testing.T) {
+	generated := []byte("package a\n\n// zero:begin custom\n// zero:end custom\n")
+
+	for _, existing := range []string{
+		"package a\n\n// zero:begin custom\nfunc f() {}\n",
+		"package a\n\n// zero:begin custom\nfunc f() {}\n// zero:end custom\n// zero:end custom\n",
+		"package a\n\n// zero:begin custom\nfunc f() {}\n// zero:end custom\n// zero:begin custom\n// zero:end custom\n",
+	} {
+		path := writeRegionFile(t, existing)
+		if _, err := preserveRegions(path, generated); err == nil || !strings.Contains(err.Error(), "解析文件 "+path+" 的保护区域失败") {
+			t.Errorf("已有文件为:\n%s\npreserveRegions 返回 %v，期望报错而不是丢弃代码", existing, err)
+		}
+	}
+
+	path := writeRegionFile(t, "// zero:begin custom\nfunc f() {}\n// zero:end custom\n")
+	if _, err := preserveRegions(path, []byte("// zero:begin custom\n")); err == nil || !strings.Contains(err.Error(), "解析模板生成的保护区域失败") {
+		t.Errorf("模板中的标记损坏时 preserveRegions 返回 %v，期望报错", err)
+	}
+}
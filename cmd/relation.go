@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/naming"
+)
+
+/*
+   @NAME    : relation
+   @author  : 清风
+   @desc    : 关联关系推断与配置合并
+   @time    : 2026/10/17
+*/
+
+// inferRelations 根据外键约束推断关联关系
+//   - 引用表 -> 被引用表: belongs_to
+//   - 被引用表 -> 引用表: has_many，外键列唯一时为 has_one
+//   - 表仅由两个外键列组成时视为连接表，两端互为 many2many
+//
+// 只处理单列外键，且两端的表都需要在本次生成的表中。
+// infer 为 naming 时，没有外键约束的 xxx_id 列也会按命名约定参与推断，为 none 时不推断
+func inferRelations(tables []*config.TableInfo, cfg *config.Config) {
+	if cfg.RelationInfer == "none" {
+		return
+	}
+
+	tableMap := make(map[string]*config.TableInfo, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+
+	foreignKeyMap := make(map[string][]config.ForeignKeyInfo, len(tables))
+	for _, table := range tables {
+		foreignKeyMap[table.Name] = singleForeignKeys(table, tableMap)
+	}
+
+	if cfg.RelationInfer == "naming" {
+		ambiguous := inferNamingForeignKeys(tables, tableMap, foreignKeyMap, cfg)
+		if len(ambiguous) > 0 {
+			fmt.Println("\n以下字段按命名约定匹配到多个表，未推断关联关系，可在 relations 中显式配置:")
+			for _, item := range ambiguous {
+				fmt.Printf("  - %s\n", item)
+			}
+		}
+	}
+
+	for _, table := range tables {
+		foreignKeys := foreignKeyMap[table.Name]
+		joinTable := isJoinTable(table, foreignKeys)
+
+		// 连接表：两端互为 many2many，连接表本身保留 belongs_to
+		if joinTable {
+			left, right := foreignKeys[0], foreignKeys[1]
+			addManyToMany(tableMap[left.RefTable], tableMap[right.RefTable], table, left, right, cfg)
+			if left.RefTable != right.RefTable {
+				addManyToMany(tableMap[right.RefTable], tableMap[left.RefTable], table, right, left, cfg)
+			}
+		}
+
+		for _, fk := range foreignKeys {
+			refTable := tableMap[fk.RefTable]
+			column, refColumn := fk.Fields[0], fk.RefFields[0]
+
+			// belongs_to，关联名优先取外键列去掉 _id 后缀，如 user_id -> user
+			addRelation(table, config.RelationInfo{
+				Type:       "belongs_to",
+				Model:      refTable.Name,
+				ForeignKey: column,
+				References: refColumn,
+				Comment:    relationComment(refTable),
+			}, cfg, strings.TrimSuffix(column, "_id"), trimTableName(refTable.Name, cfg), column+"_"+trimTableName(refTable.Name, cfg))
+
+			if joinTable {
+				continue
+			}
+
+			relType := "has_many"
+			if isUniqueColumn(table, column) {
+				relType = "has_one"
+			}
+			addRelation(refTable, config.RelationInfo{
+				Type:       relType,
+				Model:      table.Name,
+				ForeignKey: column,
+				References: refColumn,
+				Comment:    relationComment(table),
+			}, cfg, trimTableName(table.Name, cfg), trimTableName(table.Name, cfg)+"_"+strings.TrimSuffix(column, "_id"))
+		}
+	}
+}
+
+// singleForeignKeys 返回表中可用于推断的单列外键
+func singleForeignKeys(table *config.TableInfo, tableMap map[string]*config.TableInfo) []config.ForeignKeyInfo {
+	var foreignKeys []config.ForeignKeyInfo
+	for _, fk := range table.ForeignKeys {
+		if len(fk.Fields) != 1 || len(fk.RefFields) != 1 {
+			continue
+		}
+		if _, ok := tableMap[fk.RefTable]; !ok {
+			continue
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	return foreignKeys
+}
+
+// inferNamingForeignKeys 按命名约定为没有外键约束的 xxx_id 列推断外键，
+// xxx_id 匹配表 xxx 或其复数形式（考虑表名前缀），引用目标表的 id 列。
+// 已有外键约束或已在配置中声明关联的列会被跳过，返回匹配到多个表的歧义列表
+func inferNamingForeignKeys(tables []*config.TableInfo, tableMap map[string]*config.TableInfo,
+	foreignKeyMap map[string][]config.ForeignKeyInfo, cfg *config.Config) []string {
+	var ambiguous []string
+	for _, table := range tables {
+		for _, field := range table.Fields {
+			if field.IsPrimary || !strings.HasSuffix(field.Name, "_id") || hasForeignKey(foreignKeyMap[table.Name], field.Name) {
+				continue
+			}
+			if isConfiguredForeignKey(cfg.Relations[table.Name], field.Name) {
+				continue
+			}
+
+			var matches []string
+			for _, candidate := range namingCandidates(strings.TrimSuffix(field.Name, "_id"), cfg) {
+				if target, ok := tableMap[candidate]; ok && hasField(target, "id") {
+					matches = append(matches, candidate)
+				}
+			}
+
+			switch len(matches) {
+			case 0:
+			case 1:
+				foreignKeyMap[table.Name] = append(foreignKeyMap[table.Name], config.ForeignKeyInfo{
+					Name:      "naming_" + table.Name + "_" + field.Name,
+					Fields:    []string{field.Name},
+					RefTable:  matches[0],
+					RefFields: []string{"id"},
+				})
+			default:
+				ambiguous = append(ambiguous, fmt.Sprintf("%s.%s -> %s", table.Name, field.Name, strings.Join(matches, ", ")))
+			}
+		}
+	}
+	return ambiguous
+}
+
+// namingCandidates 返回 xxx_id 列可能对应的表名：单数、复数，以及加上各个前缀、后缀后的形式
+func namingCandidates(base string, cfg *config.Config) []string {
+	prefixes := append([]string{""}, cfg.Prefix...)
+	suffixes := append([]string{""}, cfg.Suffix...)
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, name := range []string{base, inflection.Plural(base), inflection.Singular(base)} {
+		for _, prefix := range prefixes {
+			for _, suffix := range suffixes {
+				candidate := prefix + name + suffix
+				if !seen[candidate] {
+					seen[candidate] = true
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// hasForeignKey 判断列是否已有外键约束
+func hasForeignKey(foreignKeys []config.ForeignKeyInfo, column string) bool {
+	for _, fk := range foreignKeys {
+		if fk.Fields[0] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfiguredForeignKey 判断列是否已在配置中作为外键声明了关联关系
+func isConfiguredForeignKey(relations []config.Relation, column string) bool {
+	for _, rel := range relations {
+		if rel.ForeignKey == column {
+			return true
+		}
+	}
+	return false
+}
+
+// hasField 判断表中是否存在指定字段
+func hasField(table *config.TableInfo, name string) bool {
+	for _, field := range table.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isJoinTable 判断表是否仅由两个外键列组成
+func isJoinTable(table *config.TableInfo, foreignKeys []config.ForeignKeyInfo) bool {
+	if len(foreignKeys) != 2 || len(table.Fields) != 2 {
+		return false
+	}
+	return foreignKeys[0].Fields[0] != foreignKeys[1].Fields[0]
+}
+
+// isUniqueColumn 判断列本身是否唯一（单列主键或单列唯一索引）
+func isUniqueColumn(table *config.TableInfo, column string) bool {
+	for _, index := range table.Indexes {
+		if index.IsUniq && len(index.Fields) == 1 && index.Fields[0] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// addManyToMany 为 owner 添加经由连接表指向 target 的 many2many 关联
+func addManyToMany(owner, target, joinTable *config.TableInfo, ownerFK, targetFK config.ForeignKeyInfo, cfg *config.Config) {
+	addRelation(owner, config.RelationInfo{
+		Type:           "many2many",
+		Model:          target.Name,
+		ForeignKey:     ownerFK.RefFields[0],
+		References:     targetFK.RefFields[0],
+		JoinTable:      joinTable.Name,
+		JoinForeignKey: ownerFK.Fields[0],
+		JoinReferences: targetFK.Fields[0],
+		Comment:        relationComment(target),
+	}, cfg, trimTableName(target.Name, cfg), trimTableName(joinTable.Name, cfg))
+}
+
+// addRelation 添加关联关系，依次尝试候选名称，避免与字段或已有关联重名。
+// 已存在描述同一组外键的关联时不再重复添加（如从快照中读取的表结构已包含关联关系）
+func addRelation(table *config.TableInfo, relation config.RelationInfo, cfg *config.Config, names ...string) {
+	for _, existing := range table.Relations {
+		if sameRelation(existing, relation) {
+			return
+		}
+	}
+
+	namer := newNamer(cfg)
+	renames := lowerKeys(cfg.Naming.Columns)
+	for _, name := range names {
+		if !relationNameTaken(table, name, namer, renames) {
+			relation.Name = name
+			table.Relations = append(table.Relations, relation)
+			return
+		}
+	}
+
+	base := names[len(names)-1]
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s_%d", base, i)
+		if !relationNameTaken(table, name, namer, renames) {
+			relation.Name = name
+			table.Relations = append(table.Relations, relation)
+			return
+		}
+	}
+}
+
+// relationNameTaken 判断关联名生成的字段名是否已被占用，与 applyNames 一样按命名词典和 naming.columns 确定字段名
+func relationNameTaken(table *config.TableInfo, name string, namer *naming.Namer, renames map[string]string) bool {
+	goName := relationGoName(name, namer)
+	for _, field := range table.Fields {
+		if columnGoName(table.Name, field.Name, namer, renames) == goName {
+			return true
+		}
+	}
+	for _, rel := range table.Relations {
+		if relationGoName(rel.Name, namer) == goName {
+			return true
+		}
+	}
+	return false
+}
+
+// relationComment 关联关系注释，优先使用表注释
+func relationComment(table *config.TableInfo) string {
+	if table.Comment != "" {
+		return table.Comment
+	}
+	return table.Name
+}
+
+// applyRelations 将配置中的关联关系合并到表结构上
+// 与推断结果同名，或指向同一模型且外键相同的关联会被配置覆盖，其余的追加
+func applyRelations(tables []*config.TableInfo, cfg *config.Config) {
+	for _, table := range tables {
+		for _, relation := range buildRelations(cfg.Relations[table.Name], cfg) {
+			replaced := false
+			for i, existing := range table.Relations {
+				if existing.Name == relation.Name || sameRelation(existing, relation) {
+					table.Relations[i] = relation
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				table.Relations = append(table.Relations, relation)
+			}
+		}
+	}
+}
+
+// sameRelation 判断两个关联是否描述的是同一组外键
+func sameRelation(a, b config.RelationInfo) bool {
+	return a.Type == b.Type &&
+		a.Model == b.Model &&
+		a.ForeignKey == b.ForeignKey &&
+		a.JoinTable == b.JoinTable &&
+		a.JoinForeignKey == b.JoinForeignKey
+}
+
+// buildRelations 将配置中的关联关系转换为关联关系信息，关联名为去除前缀、后缀后的目标表名
+func buildRelations(relations []config.Relation, cfg *config.Config) []config.RelationInfo {
+	var infos []config.RelationInfo
+	for _, rel := range relations {
+		infos = append(infos, config.RelationInfo{
+			Name:           trimTableName(rel.Target, cfg),
+			Type:           rel.Type,
+			Model:          rel.Target,
+			ForeignKey:     rel.ForeignKey,
+			References:     rel.References,
+			JoinTable:      rel.JoinTable,
+			JoinForeignKey: rel.JoinForeignKey,
+			JoinReferences: rel.JoinReferences,
+			Comment:        rel.Comment,
+		})
+	}
+	return infos
+}
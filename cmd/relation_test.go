@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// relationTable 创建测试用的表：id 为主键（没有 id 列时不设主键），unique 中的列建唯一索引
+func relationTable(name string, columns []string, unique []string, foreignKeys ...config.ForeignKeyInfo) *config.TableInfo {
+	table := &config.TableInfo{Name: name, ForeignKeys: foreignKeys}
+	for _, column := range columns {
+		table.Fields = append(table.Fields, config.FieldInfo{Name: column, Type: "int64", IsPrimary: column == "id"})
+		if column == "id" {
+			table.Indexes = append(table.Indexes, config.IndexInfo{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true})
+		}
+	}
+	for _, column := range unique {
+		table.Indexes = append(table.Indexes, config.IndexInfo{Name: "uk_" + column, Fields: []string{column}, IsUniq: true})
+	}
+	return table
+}
+
+// foreignKey 创建引用 refTable.id 的单列外键
+func foreignKey(column, refTable string) config.ForeignKeyInfo {
+	return config.ForeignKeyInfo{Name: "fk_" + column, Fields: []string{column}, RefTable: refTable, RefFields: []string{"id"}}
+}
+
+// describeRelations 将表的关联关系转换为便于比较的文本，键为表名
+func describeRelations(tables []*config.TableInfo) map[string][]string {
+	result := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		var lines []string
+		for _, rel := range table.Relations {
+			line := fmt.Sprintf("%s %s -> %s(%s,%s)", rel.Type, rel.Name, rel.Model, rel.ForeignKey, rel.References)
+			if rel.JoinTable != "" {
+				line += fmt.Sprintf(" via %s(%s,%s)", rel.JoinTable, rel.JoinForeignKey, rel.JoinReferences)
+			}
+			lines = append(lines, line)
+		}
+		result[table.Name] = lines
+	}
+	return result
+}
+
+func TestInferRelationsFromForeignKeys(t *testing.T) {
+	tables := []*config.TableInfo{
+		relationTable("users", []string{"id", "name"}, nil),
+		relationTable("profiles", []string{"id", "user_id"}, []string{"user_id"}, foreignKey("user_id", "users")),
+		relationTable("posts", []string{"id", "user_id"}, nil, foreignKey("user_id", "users")),
+		relationTable("tags", []string{"id"}, nil),
+		relationTable("post_tags", []string{"post_id", "tag_id"}, nil, foreignKey("post_id", "posts"), foreignKey("tag_id", "tags")),
+		// 引用的表不在本次生成的表中，不推断
+		relationTable("logs", []string{"id", "org_id"}, nil, foreignKey("org_id", "orgs")),
+	}
+	inferRelations(tables, &config.Config{})
+
+	want := map[string][]string{
+		"users": {
+			"has_one profiles -> profiles(user_id,id)",
+			"has_many posts -> posts(user_id,id)",
+		},
+		"profiles": {"belongs_to user -> users(user_id,id)"},
+		"posts": {
+			"belongs_to user -> users(user_id,id)",
+			"many2many tags -> tags(id,id) via post_tags(post_id,tag_id)",
+		},
+		"tags": {"many2many posts -> posts(id,id) via post_tags(tag_id,post_id)"},
+		"post_tags": {
+			"belongs_to post -> posts(post_id,id)",
+			"belongs_to tag -> tags(tag_id,id)",
+		},
+		"logs": nil,
+	}
+	if got := describeRelations(tables); !reflect.DeepEqual(got, want) {
+		t.Errorf("推断的关联关系:\n得到 %q\n期望 %q", got, want)
+	}
+}
+
+func TestInferRelationsAvoidsNameClashes(t *testing.T) {
+	// posts 有 user 列，关联名依次尝试 user、users；两个外键指向同一张表时用列名区分
+	tables := []*config.TableInfo{
+		relationTable("users", []string{"id"}, nil),
+		relationTable("posts", []string{"id", "user", "user_id", "editor_id"}, nil,
+			foreignKey("user_id", "users"), foreignKey("editor_id", "users")),
+	}
+	inferRelations(tables, &config.Config{})
+
+	want := []string{
+		"belongs_to users -> users(user_id,id)",
+		"belongs_to editor -> users(editor_id,id)",
+	}
+	if got := describeRelations(tables)["posts"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("posts 的关联关系:\n得到 %q\n期望 %q", got, want)
+	}
+	want = []string{
+		"has_many posts -> posts(user_id,id)",
+		"has_many posts_editor -> posts(editor_id,id)",
+	}
+	if got := describeRelations(tables)["users"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("users 的关联关系:\n得到 %q\n期望 %q", got, want)
+	}
+}
+
+func TestApplyRelations(t *testing.T) {
+	tables := []*config.TableInfo{
+		relationTable("users", []string{"id"}, nil),
+		relationTable("posts", []string{"id", "user_id", "editor_id"}, nil,
+			foreignKey("user_id", "users"), foreignKey("editor_id", "users")),
+		relationTable("comments", []string{"id", "post_id"}, nil),
+	}
+	cfg := &config.Config{
+		Relations: map[string][]config.Relation{
+			"posts": {
+				// 与推断的关联外键相同，覆盖推断结果（关联名变为 users）
+				{Target: "users", Type: "belongs_to", ForeignKey: "user_id", References: "id", Comment: "作者"},
+				// 追加推断不出的关联
+				{Target: "comments", Type: "has_many", ForeignKey: "post_id", References: "id"},
+			},
+		},
+	}
+	inferRelations(tables, cfg)
+	applyRelations(tables, cfg)
+
+	want := []string{
+		"belongs_to users -> users(user_id,id)",
+		"belongs_to editor -> users(editor_id,id)",
+		"has_many comments -> comments(post_id,id)",
+	}
+	posts := tables[1]
+	if got := describeRelations(tables)["posts"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("posts 的关联关系:\n得到 %q\n期望 %q", got, want)
+	}
+	if posts.Relations[0].Comment != "作者" {
+		t.Errorf("配置的关联注释为 %q，期望覆盖为 作者", posts.Relations[0].Comment)
+	}
+
+}
+
+func TestInferNamingForeignKeys(t *testing.T) {
+	tables := []*config.TableInfo{
+		relationTable("t_users", []string{"id"}, nil),
+		relationTable("category", []string{"id"}, nil),
+		relationTable("categories", []string{"id"}, nil),
+		relationTable("orders", []string{"id", "user_id", "category_id", "region_id", "shop_id"}, nil),
+	}
+	cfg := &config.Config{
+		RelationInfer: "naming",
+		Prefix:        []string{"t_"},
+		Relations: map[string][]config.Relation{
+			// 配置中已声明的外键列不再按命名约定推断
+			"orders": {{Target: "shops", Type: "belongs_to", ForeignKey: "shop_id", References: "id"}},
+		},
+	}
+	tableMap := make(map[string]*config.TableInfo, len(tables))
+	foreignKeyMap := make(map[string][]config.ForeignKeyInfo, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+
+	ambiguous := inferNamingForeignKeys(tables, tableMap, foreignKeyMap, cfg)
+	wantAmbiguous := []string{"orders.category_id -> category, categories"}
+	if !reflect.DeepEqual(ambiguous, wantAmbiguous) {
+		t.Errorf("歧义列表为 %q，期望 %q", ambiguous, wantAmbiguous)
+	}
+	wantForeignKeys := []config.ForeignKeyInfo{
+		{Name: "naming_orders_user_id", Fields: []string{"user_id"}, RefTable: "t_users", RefFields: []string{"id"}},
+	}
+	if !reflect.DeepEqual(foreignKeyMap["orders"], wantForeignKeys) {
+		t.Errorf("按命名约定推断的外键为 %+v，期望 %+v", foreignKeyMap["orders"], wantForeignKeys)
+	}
+}
+
+func TestInferRelationsModes(t *testing.T) {
+	newTables := func() []*config.TableInfo {
+		return []*config.TableInfo{
+			relationTable("users", []string{"id"}, nil),
+			relationTable("posts", []string{"id", "user_id", "author_id"}, nil, foreignKey("author_id", "users")),
+		}
+	}
+	tests := []struct {
+		infer string
+		want  []string // posts 的关联关系
+	}{
+		{infer: "", want: []string{"belongs_to author -> users(author_id,id)"}},
+		{infer: "fk", want: []string{"belongs_to author -> users(author_id,id)"}},
+		{infer: "naming", want: []string{"belongs_to author -> users(author_id,id)", "belongs_to user -> users(user_id,id)"}},
+		{infer: "none", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.infer, func(t *testing.T) {
+			tables := newTables()
+			inferRelations(tables, &config.Config{RelationInfer: tt.infer})
+			if got := describeRelations(tables)["posts"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("posts 的关联关系:\n得到 %q\n期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRelationsWithoutInference(t *testing.T) {
+	tables := []*config.TableInfo{
+		relationTable("users", []string{"id"}, nil),
+		relationTable("posts", []string{"id", "user_id", "editor_id"}, nil,
+			foreignKey("user_id", "users"), foreignKey("editor_id", "users")),
+		relationTable("comments", []string{"id", "post_id"}, nil),
+	}
+	cfg := &config.Config{
+		RelationInfer: "none",
+		Relations: map[string][]config.Relation{
+			"posts": {
+				{Target: "users", Type: "belongs_to", ForeignKey: "user_id", References: "id"},
+				{Target: "comments", Type: "has_many", ForeignKey: "post_id", References: "id"},
+			},
+		},
+	}
+	inferRelations(tables, cfg)
+	applyRelations(tables, cfg)
+
+	// 关闭推断后只保留配置的关联
+	want := []string{
+		"belongs_to users -> users(user_id,id)",
+		"has_many comments -> comments(post_id,id)",
+	}
+	if got := describeRelations(tables)["posts"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("关闭推断后 posts 的关联关系:\n得到 %q\n期望 %q", got, want)
+	}
+	if got := describeRelations(tables)["users"]; got != nil {
+		t.Errorf("关闭推断后 users 不应有关联关系，得到 %q", got)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : schema
+   @author  : 清风
+   @desc    : 表结构提供者接口及注册表
+   @time    : 2026/10/17
+*/
+
+// SchemaProvider 表结构提供者，负责从数据源（数据库、DDL 文件等）读取表结构
+type SchemaProvider interface {
+	// Tables 返回需要生成代码的表结构信息，按配置中的 Tables 过滤，为空表示所有表
+	Tables(ctx context.Context) ([]*config.TableInfo, error)
+}
+
+// ProviderFactory 根据配置创建表结构提供者
+type ProviderFactory func(cfg *config.Config) (SchemaProvider, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]ProviderFactory)
+)
+
+func init() {
+	RegisterProvider("mysql", func(cfg *config.Config) (SchemaProvider, error) {
+		return &mysqlProvider{cfg: cfg}, nil
+	})
+	RegisterProvider("postgres", func(cfg *config.Config) (SchemaProvider, error) {
+		return &postgresProvider{cfg: cfg}, nil
+	})
+	RegisterProvider("sqlite", func(cfg *config.Config) (SchemaProvider, error) {
+		return &sqliteProvider{cfg: cfg}, nil
+	})
+	RegisterProvider("ddl", func(cfg *config.Config) (SchemaProvider, error) {
+		return &ddlProvider{cfg: cfg}, nil
+	})
+	RegisterProvider("snapshot", func(cfg *config.Config) (SchemaProvider, error) {
+		return &snapshotProvider{cfg: cfg}, nil
+	})
+}
+
+// RegisterProvider 注册表结构提供者，name 对应配置中的 driver，重复注册会覆盖之前的实现
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = factory
+}
+
+// Providers 返回已注册的表结构提供者名称
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasProvider 判断是否注册了指定名称的表结构提供者
+func HasProvider(name string) bool {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	_, ok := providers[name]
+	return ok
+}
+
+// NewSchemaProvider 根据配置创建表结构提供者，
+// 指定了快照文件时使用 snapshot，指定了 DDL 文件时使用 ddl，否则使用 driver
+func NewSchemaProvider(cfg *config.Config) (SchemaProvider, error) {
+	name := cfg.Driver
+	switch {
+	case cfg.Snapshot != "":
+		name = "snapshot"
+	case cfg.DDL != "":
+		name = "ddl"
+	}
+	if name == "" {
+		name = "mysql"
+	}
+
+	providersMu.RLock()
+	factory, ok := providers[name]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("不支持的数据库驱动: %s", name)
+	}
+	return factory(cfg)
+}
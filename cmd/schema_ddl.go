@@ -0,0 +1,1292 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : schema_ddl
+   @author  : 清风
+   @desc    : 解析 MySQL DDL 文件（CREATE TABLE / ALTER TABLE 等）获取表结构
+   @time    : 2026/10/17
+*/
+
+// ddlProvider DDL 文件 表结构提供者
+type ddlProvider struct {
+	cfg *config.Config
+}
+
+// Tables 获取表结构信息
+func (p *ddlProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	cfg := p.cfg
+
+	files, err := ddlFiles(cfg.DDL)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按文件名顺序依次执行，后面的 ALTER TABLE 作用于前面创建的表
+	schema := newDDLSchema()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取 DDL 文件 %s 失败: %v", file, err)
+		}
+		if err := schema.Exec(string(content)); err != nil {
+			return nil, fmt.Errorf("解析 DDL 文件 %s 失败: %v", file, err)
+		}
+		for _, stmt := range schema.skipped {
+			fmt.Printf("  警告: 忽略 DDL 文件 %s 中不支持的语句: %s\n", file, stmt)
+		}
+		schema.skipped = nil
+	}
+
+	fmt.Printf("解析 DDL 文件成功(%d个)\n", len(files))
+
+	var tableNames []string
+	if len(cfg.Tables) == 0 {
+		for name := range schema.tables {
+			tableNames = append(tableNames, name)
+		}
+		sort.Strings(tableNames)
+		fmt.Printf("未指定表名，将生成所有表(%d个)的代码\n", len(tableNames))
+	} else {
+		tableNames = cfg.Tables
+		fmt.Printf("将生成指定的%d个表的代码\n", len(tableNames))
+	}
+
+	var tableInfos []*config.TableInfo
+	for _, tableName := range tableNames {
+		if tableName == "" {
+			continue
+		}
+
+		table, ok := schema.tables[strings.ToLower(tableName)]
+		if !ok {
+			return nil, fmt.Errorf("表 %s 不存在", tableName)
+		}
+
+		tableInfo := table.tableInfo()
+
+		tableInfos = append(tableInfos, tableInfo)
+	}
+
+	return tableInfos, nil
+}
+
+// ddlFiles 展开 DDL 文件路径，支持单个文件、目录（目录下所有 .sql 文件）和 glob
+func ddlFiles(pattern string) ([]string, error) {
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*.sql")
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("DDL 文件路径格式错误: %v", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("未找到 DDL 文件: %s", pattern)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ddlSchema DDL 执行后的表结构
+type ddlSchema struct {
+	tables  map[string]*ddlTable // 键为小写表名
+	skipped []string             // 忽略的 CREATE、ALTER、DROP 语句（视图、触发器等），用于输出警告
+}
+
+// ddlTable DDL 中的表定义
+type ddlTable struct {
+	name        string
+	comment     string
+	columns     []*ddlColumn
+	primaryKey  []string
+	indexes     []config.IndexInfo
+	foreignKeys []config.ForeignKeyInfo
+}
+
+// ddlColumn DDL 中的列定义
+type ddlColumn struct {
+	name       string
+	columnType string // 完整类型，如 varchar(255)、int(10) unsigned
+	nullable   bool
+	comment    string
+}
+
+func newDDLSchema() *ddlSchema {
+	return &ddlSchema{tables: make(map[string]*ddlTable)}
+}
+
+// Exec 执行一段 DDL，不关心的语句（INSERT、SET 等）会被忽略
+func (s *ddlSchema) Exec(sql string) error {
+	tokens, err := tokenizeDDL(sql)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range splitDDL(tokens, ";") {
+		if len(stmt) == 0 {
+			continue
+		}
+		p := &ddlParser{tokens: stmt}
+		if err := s.execStatement(p); err != nil {
+			var de *ddlError
+			if !errors.As(err, &de) {
+				err = &ddlError{line: p.line(), msg: err.Error()}
+			}
+			return fmt.Errorf("%v\n  语句: %s", err, joinDDL(stmt, 120))
+		}
+	}
+	return nil
+}
+
+func (s *ddlSchema) execStatement(p *ddlParser) error {
+	switch {
+	case p.acceptKeyword("CREATE"):
+		p.acceptKeyword("TEMPORARY")
+		switch {
+		case p.acceptKeyword("TABLE"):
+			return s.execCreateTable(p)
+		case p.peekKeyword("UNIQUE", "FULLTEXT", "SPATIAL", "INDEX"):
+			return s.execCreateIndex(p)
+		}
+		s.skip(p)
+		return nil
+	case p.acceptKeyword("ALTER"):
+		p.acceptKeyword("ONLINE")
+		p.acceptKeyword("IGNORE")
+		if !p.acceptKeyword("TABLE") {
+			s.skip(p)
+			return nil
+		}
+		return s.execAlterTable(p)
+	case p.acceptKeyword("DROP"):
+		p.acceptKeyword("TEMPORARY")
+		if p.acceptKeyword("INDEX") {
+			return s.execDropIndex(p)
+		}
+		if !p.acceptKeyword("TABLE") {
+			s.skip(p)
+			return nil
+		}
+		p.acceptKeyword("IF", "EXISTS")
+		for !p.eof() {
+			name, err := p.tableName()
+			if err != nil {
+				return err
+			}
+			delete(s.tables, strings.ToLower(name))
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		return nil
+	case p.acceptKeyword("RENAME", "TABLE"):
+		for !p.eof() {
+			from, err := p.tableName()
+			if err != nil {
+				return err
+			}
+			if !p.acceptKeyword("TO") {
+				return p.errorf("RENAME TABLE 缺少 TO")
+			}
+			to, err := p.tableName()
+			if err != nil {
+				return err
+			}
+			if err := s.renameTable(from, to); err != nil {
+				return err
+			}
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// skip 记录忽略的 CREATE、ALTER、DROP 语句，库相关的语句（CREATE DATABASE 等）与表结构无关，不记录
+func (s *ddlSchema) skip(p *ddlParser) {
+	if p.peekKeyword("DATABASE", "SCHEMA") {
+		return
+	}
+	s.skipped = append(s.skipped, joinDDL(p.tokens, 80))
+}
+
+// execCreateIndex 解析 CREATE [UNIQUE | FULLTEXT | SPATIAL] INDEX name [USING type] ON table (columns)
+func (s *ddlSchema) execCreateIndex(p *ddlParser) error {
+	unique := p.acceptKeyword("UNIQUE")
+	if !unique && !p.acceptKeyword("FULLTEXT") {
+		p.acceptKeyword("SPATIAL")
+	}
+	if !p.acceptKeyword("INDEX") {
+		return p.errorf("CREATE INDEX 语句缺少 INDEX")
+	}
+	name := p.identifier()
+	p.skipIndexType()
+	if !p.acceptKeyword("ON") {
+		return p.errorf("CREATE INDEX 语句缺少 ON")
+	}
+	table, err := s.table(p)
+	if err != nil {
+		return err
+	}
+	columns, err := p.keyParts()
+	if err != nil {
+		return err
+	}
+	table.addIndex(name, columns, unique)
+	return nil
+}
+
+// execDropIndex 解析 DROP INDEX name ON table
+func (s *ddlSchema) execDropIndex(p *ddlParser) error {
+	name := p.identifier()
+	if !p.acceptKeyword("ON") {
+		return p.errorf("DROP INDEX 语句缺少 ON")
+	}
+	table, err := s.table(p)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(name, "PRIMARY") {
+		table.primaryKey = nil
+		return nil
+	}
+	table.dropIndex(name)
+	return nil
+}
+
+// table 读取表名并返回已创建的表
+func (s *ddlSchema) table(p *ddlParser) (*ddlTable, error) {
+	name, err := p.tableName()
+	if err != nil {
+		return nil, err
+	}
+	table, ok := s.tables[strings.ToLower(name)]
+	if !ok {
+		return nil, p.errorf("表 %s 不存在", name)
+	}
+	return table, nil
+}
+
+func (s *ddlSchema) execCreateTable(p *ddlParser) error {
+	ifNotExists := p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	key := strings.ToLower(name)
+	if _, ok := s.tables[key]; ok {
+		if ifNotExists {
+			return nil
+		}
+		return p.errorf("表 %s 重复创建", name)
+	}
+
+	table := &ddlTable{name: name}
+
+	// CREATE TABLE a LIKE b
+	if p.acceptKeyword("LIKE") || (p.peekPunct("(") && p.peekKeywordAt(1, "LIKE")) {
+		paren := p.acceptPunct("(")
+		p.acceptKeyword("LIKE")
+		source, err := p.tableName()
+		if err != nil {
+			return err
+		}
+		if paren {
+			p.acceptPunct(")")
+		}
+		src, ok := s.tables[strings.ToLower(source)]
+		if !ok {
+			return p.errorf("表 %s 不存在", source)
+		}
+		table = src.clone(name)
+		s.tables[key] = table
+		return nil
+	}
+
+	defs, err := p.parenGroup()
+	if err != nil {
+		return err
+	}
+	for _, def := range splitDDL(defs, ",") {
+		if err := table.addDefinition(&ddlParser{tokens: def}); err != nil {
+			return err
+		}
+	}
+
+	table.parseOptions(p)
+	s.tables[key] = table
+	return nil
+}
+
+func (s *ddlSchema) execAlterTable(p *ddlParser) error {
+	table, err := s.table(p)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range splitDDL(p.rest(), ",") {
+		sp := &ddlParser{tokens: spec}
+		switch {
+		case sp.acceptKeyword("ADD"):
+			if sp.acceptKeyword("COLUMN") || !sp.peekDefinitionKeyword() {
+				// ADD [COLUMN] (col_def, ...)
+				if sp.peekPunct("(") {
+					defs, err := sp.parenGroup()
+					if err != nil {
+						return err
+					}
+					for _, def := range splitDDL(defs, ",") {
+						if err := table.addColumn(&ddlParser{tokens: def}); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				if err := table.addColumn(sp); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := table.addDefinition(sp); err != nil {
+				return err
+			}
+		case sp.acceptKeyword("DROP"):
+			switch {
+			case sp.acceptKeyword("PRIMARY", "KEY"):
+				table.primaryKey = nil
+			case sp.acceptKeyword("FOREIGN", "KEY"):
+				table.dropForeignKey(sp.identifier())
+			case sp.acceptKeyword("INDEX"), sp.acceptKeyword("KEY"):
+				table.dropIndex(sp.identifier())
+			case sp.acceptKeyword("CONSTRAINT"), sp.acceptKeyword("CHECK"):
+				symbol := sp.identifier()
+				table.dropForeignKey(symbol)
+				table.dropIndex(symbol)
+			default:
+				sp.acceptKeyword("COLUMN")
+				table.dropColumn(sp.identifier())
+			}
+		case sp.acceptKeyword("MODIFY"):
+			sp.acceptKeyword("COLUMN")
+			if err := table.changeColumn(sp, ""); err != nil {
+				return err
+			}
+		case sp.acceptKeyword("CHANGE"):
+			sp.acceptKeyword("COLUMN")
+			if err := table.changeColumn(sp, sp.identifier()); err != nil {
+				return err
+			}
+		case sp.acceptKeyword("RENAME", "COLUMN"):
+			from := sp.identifier()
+			sp.acceptKeyword("TO")
+			table.renameColumn(from, sp.identifier())
+		case sp.acceptKeyword("RENAME", "INDEX"), sp.acceptKeyword("RENAME", "KEY"):
+			from := sp.identifier()
+			sp.acceptKeyword("TO")
+			to := sp.identifier()
+			for i := range table.indexes {
+				if strings.EqualFold(table.indexes[i].Name, from) {
+					table.indexes[i].Name = to
+				}
+			}
+		case sp.acceptKeyword("RENAME"):
+			if !sp.acceptKeyword("TO") {
+				sp.acceptKeyword("AS")
+			}
+			to, err := sp.tableName()
+			if err != nil {
+				return err
+			}
+			if err := s.renameTable(table.name, to); err != nil {
+				return err
+			}
+		default:
+			// 表选项，如 COMMENT = 'xxx'、ENGINE = InnoDB
+			table.parseOptions(sp)
+		}
+	}
+	return nil
+}
+
+func (s *ddlSchema) renameTable(from, to string) error {
+	table, ok := s.tables[strings.ToLower(from)]
+	if !ok {
+		return fmt.Errorf("表 %s 不存在", from)
+	}
+	delete(s.tables, strings.ToLower(from))
+	table.name = to
+	s.tables[strings.ToLower(to)] = table
+
+	// 更新其他表指向该表的外键
+	for _, t := range s.tables {
+		for i := range t.foreignKeys {
+			if strings.EqualFold(t.foreignKeys[i].RefTable, from) {
+				t.foreignKeys[i].RefTable = to
+			}
+		}
+	}
+	return nil
+}
+
+// addDefinition 解析建表语句中的一项定义：列、主键、索引或外键
+func (t *ddlTable) addDefinition(p *ddlParser) error {
+	symbol := ""
+	if p.acceptKeyword("CONSTRAINT") {
+		if !p.peekKeyword("PRIMARY", "UNIQUE", "FOREIGN", "CHECK") {
+			symbol = p.identifier()
+		}
+	}
+
+	switch {
+	case p.acceptKeyword("PRIMARY", "KEY"):
+		p.skipIndexType()
+		columns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		t.primaryKey = columns
+	case p.acceptKeyword("UNIQUE"):
+		if !p.acceptKeyword("INDEX") {
+			p.acceptKeyword("KEY")
+		}
+		name := p.indexName()
+		if name == "" {
+			name = symbol
+		}
+		columns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		t.addIndex(name, columns, true)
+	case p.acceptKeyword("FULLTEXT"), p.acceptKeyword("SPATIAL"):
+		if !p.acceptKeyword("INDEX") {
+			p.acceptKeyword("KEY")
+		}
+		name := p.indexName()
+		columns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		t.addIndex(name, columns, false)
+	case p.acceptKeyword("INDEX"), p.acceptKeyword("KEY"):
+		name := p.indexName()
+		columns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		t.addIndex(name, columns, false)
+	case p.acceptKeyword("FOREIGN", "KEY"):
+		indexName := p.indexName()
+		columns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		if !p.acceptKeyword("REFERENCES") {
+			return p.errorf("外键定义缺少 REFERENCES")
+		}
+		refTable, err := p.tableName()
+		if err != nil {
+			return err
+		}
+		refColumns, err := p.keyParts()
+		if err != nil {
+			return err
+		}
+		// 与 MySQL 一致，索引名优先使用约束名，都未指定时以第一列命名
+		if indexName == "" {
+			indexName = symbol
+		}
+		if symbol == "" {
+			symbol = fmt.Sprintf("%s_ibfk_%d", t.name, len(t.foreignKeys)+1)
+		}
+		t.addForeignKey(symbol, indexName, columns, refTable, refColumns)
+	case p.acceptKeyword("CHECK"):
+		// 忽略检查约束
+	default:
+		return t.addColumn(p)
+	}
+	return nil
+}
+
+// addColumn 解析列定义并添加到表中，支持 FIRST / AFTER 定位
+func (t *ddlTable) addColumn(p *ddlParser) error {
+	col, attrs, err := p.columnDefinition()
+	if err != nil {
+		return err
+	}
+	if t.column(col.name) != nil {
+		return p.errorf("列 %s.%s 重复定义", t.name, col.name)
+	}
+
+	t.insertColumn(col, attrs.position, attrs.after)
+	t.applyColumnAttrs(col, attrs)
+	return nil
+}
+
+// changeColumn 处理 MODIFY / CHANGE COLUMN，oldName 为空时表示 MODIFY
+func (t *ddlTable) changeColumn(p *ddlParser, oldName string) error {
+	col, attrs, err := p.columnDefinition()
+	if err != nil {
+		return err
+	}
+	if oldName == "" {
+		oldName = col.name
+	}
+
+	index := t.columnIndex(oldName)
+	if index < 0 {
+		return p.errorf("列 %s.%s 不存在", t.name, oldName)
+	}
+	if !strings.EqualFold(oldName, col.name) {
+		t.renameColumn(oldName, col.name)
+	}
+
+	if attrs.position == "" {
+		t.columns[index] = col
+	} else {
+		t.columns = append(t.columns[:index], t.columns[index+1:]...)
+		t.insertColumn(col, attrs.position, attrs.after)
+	}
+	t.applyColumnAttrs(col, attrs)
+	return nil
+}
+
+// applyColumnAttrs 处理列定义中的行内主键和唯一键
+func (t *ddlTable) applyColumnAttrs(col *ddlColumn, attrs ddlColumnAttrs) {
+	if attrs.primary {
+		t.primaryKey = []string{col.name}
+	}
+	if attrs.unique {
+		t.addIndex(col.name, []string{col.name}, true)
+	}
+}
+
+func (t *ddlTable) insertColumn(col *ddlColumn, position, after string) {
+	switch position {
+	case "first":
+		t.columns = append([]*ddlColumn{col}, t.columns...)
+		return
+	case "after":
+		if i := t.columnIndex(after); i >= 0 {
+			t.columns = append(t.columns[:i+1], append([]*ddlColumn{col}, t.columns[i+1:]...)...)
+			return
+		}
+	}
+	t.columns = append(t.columns, col)
+}
+
+func (t *ddlTable) dropColumn(name string) {
+	index := t.columnIndex(name)
+	if index < 0 {
+		return
+	}
+	t.columns = append(t.columns[:index], t.columns[index+1:]...)
+
+	// 删除列时同步从主键和索引中移除，移除后为空的索引会被删除
+	t.primaryKey = removeName(t.primaryKey, name)
+	indexes := t.indexes[:0]
+	for _, idx := range t.indexes {
+		idx.Fields = removeName(idx.Fields, name)
+		if len(idx.Fields) > 0 {
+			indexes = append(indexes, idx)
+		}
+	}
+	t.indexes = indexes
+}
+
+func (t *ddlTable) renameColumn(from, to string) {
+	if col := t.column(from); col != nil {
+		col.name = to
+	}
+	rename := func(names []string) {
+		for i := range names {
+			if strings.EqualFold(names[i], from) {
+				names[i] = to
+			}
+		}
+	}
+	rename(t.primaryKey)
+	for i := range t.indexes {
+		rename(t.indexes[i].Fields)
+	}
+	for i := range t.foreignKeys {
+		rename(t.foreignKeys[i].Fields)
+	}
+}
+
+// addIndex 添加索引，未命名的索引按 MySQL 规则以第一列命名，重名时追加 _2、_3
+func (t *ddlTable) addIndex(name string, columns []string, unique bool) {
+	if len(columns) == 0 {
+		return
+	}
+	if name == "" {
+		name = columns[0]
+		for i := 2; t.hasIndex(name); i++ {
+			name = fmt.Sprintf("%s_%d", columns[0], i)
+		}
+	}
+	t.indexes = append(t.indexes, config.IndexInfo{
+		Name:   name,
+		Fields: columns,
+		IsUniq: unique,
+	})
+}
+
+// addForeignKey 添加外键，与 MySQL 一致，外键列没有可用索引时自动创建索引
+func (t *ddlTable) addForeignKey(symbol, indexName string, columns []string, refTable string, refColumns []string) {
+	t.foreignKeys = append(t.foreignKeys, config.ForeignKeyInfo{
+		Name:      symbol,
+		Fields:    columns,
+		RefTable:  refTable,
+		RefFields: refColumns,
+	})
+
+	if hasPrefixFields(t.primaryKey, columns) {
+		return
+	}
+	for _, idx := range t.indexes {
+		if hasPrefixFields(idx.Fields, columns) {
+			return
+		}
+	}
+	t.addIndex(indexName, columns, false)
+}
+
+func (t *ddlTable) dropIndex(name string) {
+	for i, idx := range t.indexes {
+		if strings.EqualFold(idx.Name, name) {
+			t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *ddlTable) dropForeignKey(name string) {
+	for i, fk := range t.foreignKeys {
+		if strings.EqualFold(fk.Name, name) {
+			t.foreignKeys = append(t.foreignKeys[:i], t.foreignKeys[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *ddlTable) hasIndex(name string) bool {
+	for _, idx := range t.indexes {
+		if strings.EqualFold(idx.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ddlTable) column(name string) *ddlColumn {
+	if i := t.columnIndex(name); i >= 0 {
+		return t.columns[i]
+	}
+	return nil
+}
+
+func (t *ddlTable) columnIndex(name string) int {
+	for i, col := range t.columns {
+		if strings.EqualFold(col.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseOptions 解析表选项，目前只关心 COMMENT
+func (t *ddlTable) parseOptions(p *ddlParser) {
+	for !p.eof() {
+		if p.acceptKeyword("COMMENT") {
+			p.acceptPunct("=")
+			if tok := p.next(); tok.kind == ddlString {
+				t.comment = tok.text
+			}
+			continue
+		}
+		p.next()
+	}
+}
+
+func (t *ddlTable) clone(name string) *ddlTable {
+	c := &ddlTable{
+		name:       name,
+		comment:    t.comment,
+		primaryKey: append([]string(nil), t.primaryKey...),
+	}
+	for _, col := range t.columns {
+		colCopy := *col
+		c.columns = append(c.columns, &colCopy)
+	}
+	for _, idx := range t.indexes {
+		idx.Fields = append([]string(nil), idx.Fields...)
+		c.indexes = append(c.indexes, idx)
+	}
+	// 与 MySQL 一致，CREATE TABLE ... LIKE 不复制外键
+	return c
+}
+
+// tableInfo 转换为生成代码使用的表信息
+func (t *ddlTable) tableInfo() *config.TableInfo {
+	info := &config.TableInfo{
+		Name:    t.name,
+		Comment: t.comment,
+	}
+
+	for _, col := range t.columns {
+		isPrimary := containsName(t.primaryKey, col.name)
+		// 主键列隐式 NOT NULL
+		isNullable := col.nullable && !isPrimary
+		info.Fields = append(info.Fields, buildMySQLField(col.name, col.columnType, col.comment, isNullable, isPrimary))
+	}
+
+	if len(t.primaryKey) > 0 {
+		info.Indexes = append(info.Indexes, config.IndexInfo{
+			Name:   "PRIMARY",
+			Fields: append([]string(nil), t.primaryKey...),
+			IsPK:   true,
+			IsUniq: true,
+		})
+	}
+	for _, idx := range t.indexes {
+		idx.Fields = append([]string(nil), idx.Fields...)
+		info.Indexes = append(info.Indexes, idx)
+	}
+	for _, fk := range t.foreignKeys {
+		fk.Fields = append([]string(nil), fk.Fields...)
+		fk.RefFields = append([]string(nil), fk.RefFields...)
+		info.ForeignKeys = append(info.ForeignKeys, fk)
+	}
+	return info
+}
+
+// ddlColumnAttrs 列定义中需要作用到表上的属性
+type ddlColumnAttrs struct {
+	primary  bool
+	unique   bool
+	position string // first 或 after
+	after    string
+}
+
+// ddlTypeAliases MySQL 类型别名，与 information_schema 中显示的类型保持一致
+var ddlTypeAliases = map[string]string{
+	"integer": "int",
+	"int4":    "int",
+	"int8":    "bigint",
+	"dec":     "decimal",
+	"fixed":   "decimal",
+	"numeric": "decimal",
+	"real":    "double",
+}
+
+// columnDefinition 解析列定义：列名 类型 [属性...]
+func (p *ddlParser) columnDefinition() (*ddlColumn, ddlColumnAttrs, error) {
+	var attrs ddlColumnAttrs
+
+	name := p.identifier()
+	if name == "" {
+		return nil, attrs, p.errorf("缺少列名")
+	}
+	typeTok := p.next()
+	if typeTok.kind != ddlWord {
+		return nil, attrs, p.errorf("列 %s 缺少类型", name)
+	}
+
+	col := &ddlColumn{name: name, nullable: true}
+	dataType := strings.ToLower(typeTok.text)
+	if dataType == "double" {
+		p.acceptKeyword("PRECISION")
+	}
+	if alias, ok := ddlTypeAliases[dataType]; ok {
+		dataType = alias
+	}
+
+	// 类型参数，如 (255)、(10,2)、('a','b')
+	var args []string
+	if p.peekPunct("(") {
+		group, err := p.parenGroup()
+		if err != nil {
+			return nil, attrs, err
+		}
+		for _, arg := range splitDDL(group, ",") {
+			var parts []string
+			for _, tok := range arg {
+				if tok.kind == ddlString {
+					parts = append(parts, "'"+strings.ReplaceAll(tok.text, "'", "''")+"'")
+				} else {
+					parts = append(parts, tok.text)
+				}
+			}
+			args = append(args, strings.Join(parts, ""))
+		}
+	}
+
+	columnType := dataType
+	switch dataType {
+	case "bool", "boolean":
+		columnType = "tinyint(1)"
+	case "serial":
+		// SERIAL 是 BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE 的别名
+		columnType = "bigint unsigned"
+		col.nullable = false
+		attrs.unique = true
+	default:
+		if len(args) > 0 {
+			columnType += "(" + strings.Join(args, ",") + ")"
+		}
+	}
+
+	for !p.eof() {
+		switch {
+		case p.acceptKeyword("UNSIGNED"):
+			columnType += " unsigned"
+		case p.acceptKeyword("ZEROFILL"):
+			columnType += " zerofill"
+		case p.acceptKeyword("NOT", "NULL"):
+			col.nullable = false
+		case p.acceptKeyword("NULL"):
+			col.nullable = true
+		case p.acceptKeyword("PRIMARY", "KEY"), p.acceptKeyword("KEY"):
+			attrs.primary = true
+			col.nullable = false
+		case p.acceptKeyword("UNIQUE"):
+			p.acceptKeyword("KEY")
+			attrs.unique = true
+		case p.acceptKeyword("COMMENT"):
+			if tok := p.next(); tok.kind == ddlString {
+				col.comment = tok.text
+			}
+		case p.acceptKeyword("CHARACTER", "SET"), p.acceptKeyword("CHARSET"), p.acceptKeyword("COLLATE"), p.acceptKeyword("DEFAULT"):
+			p.skipValue()
+		case p.acceptKeyword("REFERENCES"):
+			// 与 MySQL 一致，忽略行内 REFERENCES，不创建外键
+			if _, err := p.tableName(); err != nil {
+				return nil, attrs, err
+			}
+			if _, err := p.keyParts(); err != nil {
+				return nil, attrs, err
+			}
+		case p.acceptKeyword("FIRST"):
+			attrs.position = "first"
+		case p.acceptKeyword("AFTER"):
+			attrs.position, attrs.after = "after", p.identifier()
+		default:
+			// AUTO_INCREMENT、ON UPDATE、GENERATED ALWAYS AS (...) 等与结构无关的属性
+			p.skipValue()
+		}
+	}
+
+	col.columnType = columnType
+	return col, attrs, nil
+}
+
+// ddlTokenKind 词法单元类型
+type ddlTokenKind int
+
+const (
+	ddlWord   ddlTokenKind = iota // 关键字、未加引号的标识符、数字
+	ddlIdent                      // 反引号标识符
+	ddlString                     // 字符串
+	ddlPunct                      // 标点符号
+)
+
+// ddlToken 词法单元
+type ddlToken struct {
+	kind ddlTokenKind
+	text string
+	line int // 所在行号，从 1 开始
+}
+
+// tokenizeDDL 将 SQL 切分为词法单元，注释（包括 /*! ... */ 条件注释）会被丢弃。
+// 注释、标识符或字符串未闭合时，错误信息中包含其开始的行号
+func tokenizeDDL(sql string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	src := []rune(sql)
+	// lineAt 返回第 i 个字符所在的行号，i 只会递增，已统计过的换行不再重复统计
+	line, counted := 1, 0
+	lineAt := func(i int) int {
+		for ; counted < i && counted < len(src); counted++ {
+			if src[counted] == '\n' {
+				line++
+			}
+		}
+		return line
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		start := lineAt(i)
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#' || (c == '-' && i+1 < len(src) && src[i+1] == '-'):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i+1 < len(src) && !(src[i] == '*' && src[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(src) {
+				return nil, &ddlError{line: start, msg: "注释未闭合"}
+			}
+			i += 2
+		case c == '`':
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, &ddlError{line: start, msg: "标识符未闭合"}
+				}
+				if src[i] == '`' {
+					if i+1 < len(src) && src[i+1] == '`' {
+						sb.WriteRune('`')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteRune(src[i])
+				i++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlIdent, text: sb.String(), line: start})
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, &ddlError{line: start, msg: "字符串未闭合"}
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					sb.WriteRune(unescapeDDL(src[i+1]))
+					i += 2
+					continue
+				}
+				if src[i] == c {
+					if i+1 < len(src) && src[i+1] == c {
+						sb.WriteRune(c)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteRune(src[i])
+				i++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlString, text: sb.String(), line: start})
+		case isDDLWordRune(c):
+			begin := i
+			for i < len(src) && isDDLWordRune(src[i]) {
+				i++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlWord, text: string(src[begin:i]), line: start})
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlPunct, text: string(c), line: start})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isDDLWordRune(r rune) bool {
+	return r == '_' || r == '$' || r > 127 ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func unescapeDDL(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case '0':
+		return 0
+	default:
+		return r
+	}
+}
+
+// splitDDL 按顶层（不在括号内）的分隔符切分词法单元
+func splitDDL(tokens []ddlToken, sep string) [][]ddlToken {
+	var parts [][]ddlToken
+	depth, start := 0, 0
+	for i, tok := range tokens {
+		if tok.kind != ddlPunct {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+// joinDDL 将词法单元还原为便于阅读的文本，用于错误信息
+func joinDDL(tokens []ddlToken, limit int) string {
+	var parts []string
+	for _, tok := range tokens {
+		switch tok.kind {
+		case ddlIdent:
+			parts = append(parts, "`"+tok.text+"`")
+		case ddlString:
+			parts = append(parts, "'"+tok.text+"'")
+		default:
+			parts = append(parts, tok.text)
+		}
+	}
+	text := strings.Join(parts, " ")
+	if r := []rune(text); len(r) > limit {
+		text = string(r[:limit]) + "..."
+	}
+	return text
+}
+
+// ddlParser 基于词法单元的简单递归下降解析器
+type ddlParser struct {
+	tokens []ddlToken
+	pos    int
+}
+
+func (p *ddlParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ddlParser) next() ddlToken {
+	if p.eof() {
+		return ddlToken{kind: ddlPunct}
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok
+}
+
+// ddlError 带行号的 DDL 解析错误
+type ddlError struct {
+	line int
+	msg  string
+}
+
+func (e *ddlError) Error() string {
+	return fmt.Sprintf("第 %d 行: %s", e.line, e.msg)
+}
+
+// errorf 返回位于解析停止处的错误
+func (p *ddlParser) errorf(format string, args ...interface{}) error {
+	return &ddlError{line: p.line(), msg: fmt.Sprintf(format, args...)}
+}
+
+// line 返回解析停止处（最后读取的词法单元）所在的行号，用于错误信息
+func (p *ddlParser) line() int {
+	if len(p.tokens) == 0 {
+		return 0
+	}
+	pos := p.pos - 1
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(p.tokens) {
+		pos = len(p.tokens) - 1
+	}
+	return p.tokens[pos].line
+}
+
+func (p *ddlParser) rest() []ddlToken {
+	return p.tokens[p.pos:]
+}
+
+// peekKeywordAt 判断 pos+offset 处是否为指定关键字
+func (p *ddlParser) peekKeywordAt(offset int, keyword string) bool {
+	i := p.pos + offset
+	return i < len(p.tokens) && p.tokens[i].kind == ddlWord && strings.EqualFold(p.tokens[i].text, keyword)
+}
+
+// peekKeyword 判断当前是否为任意一个指定关键字
+func (p *ddlParser) peekKeyword(keywords ...string) bool {
+	for _, kw := range keywords {
+		if p.peekKeywordAt(0, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptKeyword 当前位置依次匹配所有关键字时前进并返回 true
+func (p *ddlParser) acceptKeyword(keywords ...string) bool {
+	for i, kw := range keywords {
+		if !p.peekKeywordAt(i, kw) {
+			return false
+		}
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+func (p *ddlParser) peekPunct(punct string) bool {
+	return !p.eof() && p.tokens[p.pos].kind == ddlPunct && p.tokens[p.pos].text == punct
+}
+
+func (p *ddlParser) acceptPunct(punct string) bool {
+	if p.peekPunct(punct) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// peekDefinitionKeyword 判断 ALTER TABLE ADD 后面是否为索引或约束定义
+func (p *ddlParser) peekDefinitionKeyword() bool {
+	return p.peekKeyword("CONSTRAINT", "PRIMARY", "UNIQUE", "INDEX", "KEY", "FULLTEXT", "SPATIAL", "FOREIGN", "CHECK")
+}
+
+// identifier 读取一个标识符，不是标识符时返回空字符串
+func (p *ddlParser) identifier() string {
+	if p.eof() {
+		return ""
+	}
+	tok := p.tokens[p.pos]
+	if tok.kind == ddlPunct {
+		return ""
+	}
+	p.pos++
+	return tok.text
+}
+
+// tableName 读取表名，忽略库名前缀
+func (p *ddlParser) tableName() (string, error) {
+	name := p.identifier()
+	for p.acceptPunct(".") {
+		name = p.identifier()
+	}
+	if name == "" {
+		return "", p.errorf("缺少表名")
+	}
+	return name, nil
+}
+
+// indexName 读取可选的索引名
+func (p *ddlParser) indexName() string {
+	if p.peekPunct("(") || p.peekKeyword("USING") {
+		p.skipIndexType()
+		return ""
+	}
+	name := p.identifier()
+	p.skipIndexType()
+	return name
+}
+
+func (p *ddlParser) skipIndexType() {
+	if p.acceptKeyword("USING") {
+		p.next()
+	}
+}
+
+// parenGroup 读取一对括号内的词法单元
+func (p *ddlParser) parenGroup() ([]ddlToken, error) {
+	if !p.acceptPunct("(") {
+		return nil, p.errorf("缺少左括号")
+	}
+	start, depth := p.pos, 1
+	for !p.eof() {
+		tok := p.next()
+		if tok.kind != ddlPunct {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return p.tokens[start : p.pos-1], nil
+			}
+		}
+	}
+	return nil, p.errorf("括号未闭合")
+}
+
+// keyParts 读取索引列列表，忽略前缀长度、排序方向和函数索引表达式
+func (p *ddlParser) keyParts() ([]string, error) {
+	group, err := p.parenGroup()
+	if err != nil {
+		return nil, err
+	}
+	var columns []string
+	for _, part := range splitDDL(group, ",") {
+		if len(part) == 0 || part[0].kind == ddlPunct || part[0].kind == ddlString {
+			continue
+		}
+		columns = append(columns, part[0].text)
+	}
+	return columns, nil
+}
+
+// skipValue 跳过一个值（单个词法单元或一组括号）
+func (p *ddlParser) skipValue() {
+	if p.peekPunct("(") {
+		_, _ = p.parenGroup()
+		return
+	}
+	p.next()
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeName(names []string, name string) []string {
+	var result []string
+	for _, n := range names {
+		if !strings.EqualFold(n, name) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// hasPrefixFields 判断 fields 是否以 prefix 开头
+func hasPrefixFields(fields, prefix []string) bool {
+	if len(prefix) == 0 || len(fields) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if !strings.EqualFold(fields[i], prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
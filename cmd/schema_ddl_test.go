@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// describeDDLTable 将表结构转换为便于比较的文本：每列一行，其后是索引和外键
+func describeDDLTable(info *config.TableInfo) []string {
+	var lines []string
+	if info.Comment != "" {
+		lines = append(lines, "comment "+info.Comment)
+	}
+	for _, field := range info.Fields {
+		line := field.Name + " " + field.ColumnType
+		if field.IsPrimary {
+			line += " pk"
+		}
+		if field.IsNullable {
+			line += " null"
+		}
+		if field.Comment != "" {
+			line += " '" + field.Comment + "'"
+		}
+		lines = append(lines, line)
+	}
+	for _, index := range info.Indexes {
+		kind := "index"
+		switch {
+		case index.IsPK:
+			kind = "pk"
+		case index.IsUniq:
+			kind = "unique"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s(%s)", kind, index.Name, strings.Join(index.Fields, ",")))
+	}
+	for _, fk := range info.ForeignKeys {
+		lines = append(lines, fmt.Sprintf("fk %s(%s) -> %s(%s)", fk.Name, strings.Join(fk.Fields, ","), fk.RefTable, strings.Join(fk.RefFields, ",")))
+	}
+	return lines
+}
+
+func TestDDLSchemaExec(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want map[string][]string // 键为表名，值为 describeDDLTable 的结果
+	}{
+		{
+			name: "行内主键、唯一、默认值、注释和自增",
+			sql: "CREATE TABLE `users` (\n" +
+				"  `id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',\n" +
+				"  `email` varchar(64) NOT NULL UNIQUE DEFAULT '' COMMENT '邮箱',\n" +
+				"  `age` int DEFAULT NULL,\n" +
+				"  `active` boolean NOT NULL DEFAULT TRUE,\n" +
+				"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户';",
+			want: map[string][]string{"users": {
+				"comment 用户",
+				"id bigint unsigned pk '主键'",
+				"email varchar(64) '邮箱'",
+				"age int null",
+				"active tinyint(1)",
+				"updated_at datetime",
+				"pk PRIMARY(id)",
+				"unique email(email)",
+			}},
+		},
+		{
+			name: "表级主键、索引和外键",
+			sql: `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE posts (
+  id int,
+  user_id int NOT NULL,
+  title varchar(128),
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_title (title(32)),
+  KEY idx_user (user_id),
+  CONSTRAINT fk_posts_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);`,
+			want: map[string][]string{
+				"users": {"id int pk", "pk PRIMARY(id)"},
+				"posts": {
+					"id int pk",
+					"user_id int",
+					"title varchar(128) null",
+					"pk PRIMARY(id)",
+					"unique uk_title(title)",
+					"index idx_user(user_id)",
+					"fk fk_posts_user(user_id) -> users(id)",
+				},
+			},
+		},
+		{
+			name: "枚举值包含逗号和转义的引号",
+			sql:  `CREATE TABLE t (id int PRIMARY KEY, kind enum('a,b', 'it''s', 'say \"hi\"', 'x\'y') NOT NULL, tags set('x,y','z'));`,
+			want: map[string][]string{"t": {
+				"id int pk",
+				`kind enum('a,b','it''s','say "hi"','x''y')`,
+				"tags set('x,y','z') null",
+				"pk PRIMARY(id)",
+			}},
+		},
+		{
+			name: "ALTER TABLE 增删改列",
+			sql: `CREATE TABLE t (id int PRIMARY KEY, a int, b int, c int);
+ALTER TABLE t ADD COLUMN d varchar(10) AFTER id, ADD e int FIRST;
+ALTER TABLE t MODIFY a bigint NOT NULL COMMENT 'A';
+ALTER TABLE t CHANGE COLUMN b b2 varchar(20);
+ALTER TABLE t DROP COLUMN c;`,
+			want: map[string][]string{"t": {
+				"e int null",
+				"id int pk",
+				"d varchar(10) null",
+				"a bigint 'A'",
+				"b2 varchar(20) null",
+				"pk PRIMARY(id)",
+			}},
+		},
+		{
+			name: "ALTER TABLE 添加索引和外键",
+			sql: `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE posts (id int PRIMARY KEY, user_id int, slug varchar(32));
+ALTER TABLE posts ADD INDEX idx_user (user_id), ADD UNIQUE uk_slug (slug);
+ALTER TABLE posts ADD CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id);`,
+			want: map[string][]string{
+				"users": {"id int pk", "pk PRIMARY(id)"},
+				"posts": {
+					"id int pk",
+					"user_id int null",
+					"slug varchar(32) null",
+					"pk PRIMARY(id)",
+					"index idx_user(user_id)",
+					"unique uk_slug(slug)",
+					"fk fk_user(user_id) -> users(id)",
+				},
+			},
+		},
+		{
+			name: "DROP TABLE、RENAME TABLE 和 CREATE INDEX",
+			sql: `CREATE TABLE a (id int PRIMARY KEY);
+CREATE TABLE b (id int PRIMARY KEY, a_id int, FOREIGN KEY (a_id) REFERENCES a (id));
+CREATE TABLE c (id int);
+DROP TABLE IF EXISTS c, missing;
+RENAME TABLE a TO accounts;
+CREATE UNIQUE INDEX uk_a ON b (id, a_id);`,
+			want: map[string][]string{
+				"accounts": {"id int pk", "pk PRIMARY(id)"},
+				"b": {
+					"id int pk",
+					"a_id int null",
+					"pk PRIMARY(id)",
+					"index a_id(a_id)",
+					"unique uk_a(id,a_id)",
+					"fk b_ibfk_1(a_id) -> accounts(id)",
+				},
+			},
+		},
+		{
+			name: "注释",
+			sql: `-- 行注释; CREATE TABLE x (id int);
+# 井号注释; CREATE TABLE y (id int);
+/* 块注释; CREATE TABLE z (id int); */
+CREATE TABLE t (
+  id int PRIMARY KEY, -- 主键
+  name varchar(8) /* 名称 */ NOT NULL # 名称
+);`,
+			want: map[string][]string{"t": {"id int pk", "name varchar(8)", "pk PRIMARY(id)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := newDDLSchema()
+			if err := schema.Exec(tt.sql); err != nil {
+				t.Fatalf("Exec: %v", err)
+			}
+			var names []string
+			for _, table := range schema.tables {
+				names = append(names, table.name)
+			}
+			sort.Strings(names)
+			var wantNames []string
+			for name := range tt.want {
+				wantNames = append(wantNames, name)
+			}
+			sort.Strings(wantNames)
+			if !reflect.DeepEqual(names, wantNames) {
+				t.Fatalf("表为 %v，期望 %v", names, wantNames)
+			}
+			for name, want := range tt.want {
+				got := describeDDLTable(schema.tables[name].tableInfo())
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("表 %s:\n得到 %q\n期望 %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDDLSchemaExecErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{"注释未闭合", "CREATE TABLE t (id int);\n/* 注释\n", "第 2 行: 注释未闭合"},
+		{"字符串未闭合", "CREATE TABLE t (\n  id int COMMENT 'id\n);", "第 2 行: 字符串未闭合"},
+		{"标识符未闭合", "\n\nCREATE TABLE `t (id int);", "第 3 行: 标识符未闭合"},
+		{"列缺少类型", "CREATE TABLE t (\n  id int,\n  name\n);", "第 3 行: 列 name 缺少类型"},
+		{"括号未闭合", "CREATE TABLE t (id int", "第 1 行: 括号未闭合"},
+		{"表不存在", "CREATE TABLE t (id int);\n\nALTER TABLE missing ADD a int;", "第 3 行: 表 missing 不存在"},
+		{"列重复定义", "CREATE TABLE t (\n  id int,\n  id int\n);", "第 3 行: 列 t.id 重复定义"},
+		{"表重复创建", "CREATE TABLE t (id int);\nCREATE TABLE t (id int);", "第 2 行: 表 t 重复创建"},
+		{"RENAME 缺少 TO", "CREATE TABLE t (id int);\nRENAME TABLE t x;", "第 2 行: RENAME TABLE 缺少 TO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newDDLSchema().Exec(tt.sql)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Exec 返回 %v，期望包含 %q", err, tt.want)
+			}
+		})
+	}
+}
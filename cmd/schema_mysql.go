@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : schema_mysql
+   @author  : 清风
+   @desc    : 从 MySQL 的 information_schema 读取表结构
+   @time    : 2026/10/17
+*/
+
+// mysqlProvider MySQL 表结构提供者
+type mysqlProvider struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// Tables 获取表结构信息
+func (p *mysqlProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	// 连接数据库
+	db, err := gorm.Open(mysql.Open(p.cfg.DSN), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("连接数据库失败: %v", err)
+	}
+	p.db = db.WithContext(ctx)
+
+	fmt.Println("连接数据库成功")
+
+	tableNames, err := p.tableNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var tableInfos []*config.TableInfo
+
+	// 遍历处理每个表
+	for _, tableName := range tableNames {
+		if tableName == "" {
+			continue
+		}
+
+		tableInfo, err := p.table(tableName)
+		if err != nil {
+			return nil, err
+		}
+		tableInfos = append(tableInfos, tableInfo)
+	}
+
+	return tableInfos, nil
+}
+
+// tableNames 获取需要生成的表名
+func (p *mysqlProvider) tableNames() ([]string, error) {
+	if len(p.cfg.Tables) > 0 {
+		fmt.Printf("将生成指定的%d个表的代码\n", len(p.cfg.Tables))
+		return p.cfg.Tables, nil
+	}
+
+	// 如果未指定表名，则获取所有表
+	var tables []string
+	if err := p.db.Raw("SHOW TABLES").Scan(&tables).Error; err != nil {
+		return nil, fmt.Errorf("获取所有表名失败: %v", err)
+	}
+	fmt.Printf("未指定表名，将生成所有表(%d个)的代码\n", len(tables))
+	return tables, nil
+}
+
+// table 获取单个表的结构信息
+func (p *mysqlProvider) table(tableName string) (*config.TableInfo, error) {
+	tableInfo := &config.TableInfo{
+		Name: tableName,
+	}
+
+	// 获取表注释
+	row := p.db.Raw("SELECT table_comment FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", tableName).Row()
+	if err := row.Scan(&tableInfo.Comment); err != nil {
+		return nil, fmt.Errorf("获取表 %s 的注释失败: %v", tableName, err)
+	}
+
+	fields, err := p.fields(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.Fields = fields
+
+	// 主键索引（支持联合主键）
+	var primaryKeys []string
+	for _, field := range fields {
+		if field.IsPrimary {
+			primaryKeys = append(primaryKeys, field.Name)
+		}
+	}
+	if len(primaryKeys) > 0 {
+		tableInfo.Indexes = append(tableInfo.Indexes, config.IndexInfo{
+			Name:   "PRIMARY",
+			Fields: primaryKeys,
+			IsPK:   true,
+			IsUniq: true,
+		})
+	}
+
+	indexes, err := p.indexes(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.Indexes = append(tableInfo.Indexes, indexes...)
+
+	foreignKeys, err := p.foreignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.ForeignKeys = foreignKeys
+
+	return tableInfo, nil
+}
+
+// fields 获取表的字段信息
+func (p *mysqlProvider) fields(tableName string) ([]config.FieldInfo, error) {
+	type columnInfo struct {
+		ColumnName    string `gorm:"column:COLUMN_NAME"`
+		DataType      string `gorm:"column:DATA_TYPE"`
+		ColumnType    string `gorm:"column:COLUMN_TYPE"`
+		IsNullable    string `gorm:"column:IS_NULLABLE"`
+		ColumnKey     string `gorm:"column:COLUMN_KEY"`
+		ColumnDefault string `gorm:"column:COLUMN_DEFAULT"`
+		Extra         string `gorm:"column:EXTRA"`
+		ColumnComment string `gorm:"column:COLUMN_COMMENT"`
+	}
+
+	var columns []columnInfo
+	if err := p.db.Raw(`SELECT
+		COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, IS_NULLABLE, COLUMN_KEY,
+		COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
+	FROM information_schema.columns
+	WHERE table_schema = DATABASE()
+	AND table_name = ?
+	ORDER BY ORDINAL_POSITION`, tableName).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的字段信息失败: %v", tableName, err)
+	}
+
+	var fields []config.FieldInfo
+	for _, col := range columns {
+		fields = append(fields, buildMySQLField(col.ColumnName, col.ColumnType, col.ColumnComment,
+			col.IsNullable == "YES", col.ColumnKey == "PRI"))
+	}
+	return fields, nil
+}
+
+// indexes 获取表的索引信息（非主键），按索引名排序
+func (p *mysqlProvider) indexes(tableName string) ([]config.IndexInfo, error) {
+	var rows []struct {
+		IndexName string `gorm:"column:INDEX_NAME"`
+		NonUnique int    `gorm:"column:NON_UNIQUE"`
+		ColName   string `gorm:"column:COLUMN_NAME"`
+	}
+	if err := p.db.Raw(`SELECT
+		INDEX_NAME, NON_UNIQUE, COLUMN_NAME
+	FROM information_schema.statistics
+	WHERE table_schema = DATABASE()
+	AND table_name = ?
+	AND INDEX_NAME != 'PRIMARY'
+	ORDER BY INDEX_NAME, SEQ_IN_INDEX`, tableName).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的索引信息失败: %v", tableName, err)
+	}
+
+	var indexes []config.IndexInfo
+	for _, row := range rows {
+		if n := len(indexes); n > 0 && indexes[n-1].Name == row.IndexName {
+			indexes[n-1].Fields = append(indexes[n-1].Fields, row.ColName)
+			continue
+		}
+		indexes = append(indexes, config.IndexInfo{
+			Name:   row.IndexName,
+			Fields: []string{row.ColName},
+			IsUniq: row.NonUnique == 0,
+		})
+	}
+	return indexes, nil
+}
+
+// foreignKeys 获取表的外键约束
+func (p *mysqlProvider) foreignKeys(tableName string) ([]config.ForeignKeyInfo, error) {
+	var rows []struct {
+		ConstraintName string `gorm:"column:CONSTRAINT_NAME"`
+		ColName        string `gorm:"column:COLUMN_NAME"`
+		RefTable       string `gorm:"column:REFERENCED_TABLE_NAME"`
+		RefColName     string `gorm:"column:REFERENCED_COLUMN_NAME"`
+	}
+	if err := p.db.Raw(`SELECT
+		k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME
+	FROM information_schema.KEY_COLUMN_USAGE k
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+		ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+		AND r.TABLE_NAME = k.TABLE_NAME
+		AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+	WHERE k.TABLE_SCHEMA = DATABASE()
+	AND k.TABLE_NAME = ?
+	ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`, tableName).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的外键信息失败: %v", tableName, err)
+	}
+
+	var foreignKeys []config.ForeignKeyInfo
+	for _, row := range rows {
+		if n := len(foreignKeys); n > 0 && foreignKeys[n-1].Name == row.ConstraintName {
+			foreignKeys[n-1].Fields = append(foreignKeys[n-1].Fields, row.ColName)
+			foreignKeys[n-1].RefFields = append(foreignKeys[n-1].RefFields, row.RefColName)
+			continue
+		}
+		foreignKeys = append(foreignKeys, config.ForeignKeyInfo{
+			Name:      row.ConstraintName,
+			Fields:    []string{row.ColName},
+			RefTable:  row.RefTable,
+			RefFields: []string{row.RefColName},
+		})
+	}
+	return foreignKeys, nil
+}
+
+// buildMySQLField 根据 MySQL 列定义构建字段信息，字段类型按完整的列类型确定（区分 unsigned、tinyint(1) 等）
+func buildMySQLField(name, columnType, comment string, isNullable, isPrimary bool) config.FieldInfo {
+	// 处理字段类型
+	fieldType := utils.GetGoType(columnType)
+	if isNullable {
+		fieldType = utils.NullableType(fieldType, "pointer")
+	}
+
+	return config.FieldInfo{
+		Name:       name,
+		Type:       fieldType,
+		Comment:    comment,
+		IsNullable: isNullable,
+		IsPrimary:  isPrimary,
+		Tag:        utils.BuildFieldTags(name, columnType, isNullable),
+		ColumnType: columnType,
+	}
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : schema_postgres
+   @author  : 清风
+   @desc    : 从 PostgreSQL 的 pg_catalog 读取表结构
+   @time    : 2026/10/17
+*/
+
+// postgresProvider PostgreSQL 表结构提供者
+type postgresProvider struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// postgresTable pg_class 中的表
+type postgresTable struct {
+	Oid     uint32 `gorm:"column:oid"`
+	Name    string `gorm:"column:name"`
+	Comment string `gorm:"column:comment"`
+}
+
+// Tables 获取表结构信息
+func (p *postgresProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	// 连接数据库
+	db, err := gorm.Open(postgres.Open(p.cfg.DSN), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("连接数据库失败: %v", err)
+	}
+	p.db = db.WithContext(ctx)
+
+	fmt.Println("连接数据库成功")
+
+	tables, err := p.tables()
+	if err != nil {
+		return nil, err
+	}
+
+	var tableInfos []*config.TableInfo
+
+	// 遍历处理每个表
+	for _, table := range tables {
+		tableInfo, err := p.table(table)
+		if err != nil {
+			return nil, err
+		}
+		tableInfos = append(tableInfos, tableInfo)
+	}
+
+	return tableInfos, nil
+}
+
+// tables 获取需要生成的表，未指定表名时返回当前 schema 下的所有普通表和分区表
+func (p *postgresProvider) tables() ([]postgresTable, error) {
+	var tables []postgresTable
+	if err := p.db.Raw(`SELECT
+			c.oid, c.relname AS name,
+			COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') AS comment
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+		AND c.relkind IN ('r', 'p')
+		AND NOT c.relispartition
+		ORDER BY c.relname`).Scan(&tables).Error; err != nil {
+		return nil, fmt.Errorf("获取所有表名失败: %v", err)
+	}
+
+	if len(p.cfg.Tables) == 0 {
+		fmt.Printf("未指定表名，将生成所有表(%d个)的代码\n", len(tables))
+		return tables, nil
+	}
+
+	fmt.Printf("将生成指定的%d个表的代码\n", len(p.cfg.Tables))
+	tableMap := make(map[string]postgresTable, len(tables))
+	for _, t := range tables {
+		tableMap[t.Name] = t
+	}
+	var selected []postgresTable
+	for _, tableName := range p.cfg.Tables {
+		if tableName == "" {
+			continue
+		}
+		table, ok := tableMap[tableName]
+		if !ok {
+			return nil, fmt.Errorf("表 %s 不存在", tableName)
+		}
+		selected = append(selected, table)
+	}
+	return selected, nil
+}
+
+// table 获取单个表的结构信息
+func (p *postgresProvider) table(table postgresTable) (*config.TableInfo, error) {
+	tableInfo := &config.TableInfo{
+		Name:    table.Name,
+		Comment: table.Comment,
+	}
+
+	fields, err := p.fields(table)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.Fields = fields
+
+	// 主键索引（支持联合主键）
+	var primaryKeys []string
+	for _, field := range fields {
+		if field.IsPrimary {
+			primaryKeys = append(primaryKeys, field.Name)
+		}
+	}
+	if len(primaryKeys) > 0 {
+		tableInfo.Indexes = append(tableInfo.Indexes, config.IndexInfo{
+			Name:   "PRIMARY",
+			Fields: primaryKeys,
+			IsPK:   true,
+			IsUniq: true,
+		})
+	}
+
+	indexes, err := p.indexes(table)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.Indexes = append(tableInfo.Indexes, indexes...)
+
+	foreignKeys, err := p.foreignKeys(table)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.ForeignKeys = foreignKeys
+
+	return tableInfo, nil
+}
+
+// fields 获取表的字段信息
+func (p *postgresProvider) fields(table postgresTable) ([]config.FieldInfo, error) {
+	type columnInfo struct {
+		ColumnName    string `gorm:"column:column_name"`
+		UdtName       string `gorm:"column:udt_name"`
+		ColumnType    string `gorm:"column:column_type"`
+		NotNull       bool   `gorm:"column:not_null"`
+		IsPrimary     bool   `gorm:"column:is_primary"`
+		ColumnComment string `gorm:"column:column_comment"`
+	}
+
+	var columns []columnInfo
+	if err := p.db.Raw(`SELECT
+			a.attname AS column_name,
+			t.typname AS udt_name,
+			pg_catalog.format_type(a.atttypid, a.atttypmod) AS column_type,
+			a.attnotnull AS not_null,
+			EXISTS (
+				SELECT 1 FROM pg_catalog.pg_index ix
+				WHERE ix.indrelid = a.attrelid AND ix.indisprimary AND a.attnum = ANY(ix.indkey)
+			) AS is_primary,
+			COALESCE(pg_catalog.col_description(a.attrelid, a.attnum), '') AS column_comment
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+		WHERE a.attrelid = ?
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+		ORDER BY a.attnum`, table.Oid).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的字段信息失败: %v", table.Name, err)
+	}
+
+	var fields []config.FieldInfo
+	for _, col := range columns {
+		isNullable := !col.NotNull
+
+		// 处理字段类型
+		fieldType := utils.GetPostgresGoType(col.UdtName)
+		if isNullable {
+			fieldType = utils.NullableType(fieldType, "pointer")
+		}
+
+		fields = append(fields, config.FieldInfo{
+			Name:       col.ColumnName,
+			Type:       fieldType,
+			Comment:    col.ColumnComment,
+			IsNullable: isNullable,
+			IsPrimary:  col.IsPrimary,
+			Tag:        utils.BuildFieldTags(col.ColumnName, col.ColumnType, isNullable),
+			ColumnType: col.ColumnType,
+		})
+	}
+	return fields, nil
+}
+
+// indexes 获取表的索引信息（非主键），表达式索引的列不在 pg_attribute 中，会被自然忽略
+func (p *postgresProvider) indexes(table postgresTable) ([]config.IndexInfo, error) {
+	var rows []struct {
+		IndexName string `gorm:"column:index_name"`
+		IsUnique  bool   `gorm:"column:is_unique"`
+		ColName   string `gorm:"column:column_name"`
+	}
+	if err := p.db.Raw(`SELECT
+			i.relname AS index_name,
+			ix.indisunique AS is_unique,
+			a.attname AS column_name
+		FROM pg_catalog.pg_index ix
+		JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+		JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum
+		WHERE ix.indrelid = ?
+		AND NOT ix.indisprimary
+		ORDER BY i.relname, k.ord`, table.Oid).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的索引信息失败: %v", table.Name, err)
+	}
+
+	// 处理索引信息，保持索引名的顺序
+	indexMap := make(map[string]*config.IndexInfo)
+	var indexNames []string
+	for _, row := range rows {
+		if index, ok := indexMap[row.IndexName]; ok {
+			index.Fields = append(index.Fields, row.ColName)
+			continue
+		}
+		indexMap[row.IndexName] = &config.IndexInfo{
+			Name:   row.IndexName,
+			Fields: []string{row.ColName},
+			IsUniq: row.IsUnique,
+		}
+		indexNames = append(indexNames, row.IndexName)
+	}
+
+	indexes := make([]config.IndexInfo, 0, len(indexNames))
+	for _, name := range indexNames {
+		indexes = append(indexes, *indexMap[name])
+	}
+	return indexes, nil
+}
+
+// foreignKeys 获取表的外键约束，conkey 与 confkey 按位置一一对应
+func (p *postgresProvider) foreignKeys(table postgresTable) ([]config.ForeignKeyInfo, error) {
+	var rows []struct {
+		ConstraintName string `gorm:"column:constraint_name"`
+		ColName        string `gorm:"column:column_name"`
+		RefTable       string `gorm:"column:ref_table"`
+		RefColName     string `gorm:"column:ref_column"`
+	}
+	if err := p.db.Raw(`SELECT
+			con.conname AS constraint_name,
+			a.attname AS column_name,
+			rt.relname AS ref_table,
+			ra.attname AS ref_column
+		FROM pg_catalog.pg_constraint con
+		JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, refnum, ord) ON true
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		JOIN pg_catalog.pg_class rt ON rt.oid = con.confrelid
+		JOIN pg_catalog.pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refnum
+		WHERE con.conrelid = ?
+		AND con.contype = 'f'
+		ORDER BY con.conname, k.ord`, table.Oid).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的外键信息失败: %v", table.Name, err)
+	}
+
+	var foreignKeys []config.ForeignKeyInfo
+	for _, row := range rows {
+		if n := len(foreignKeys); n > 0 && foreignKeys[n-1].Name == row.ConstraintName {
+			foreignKeys[n-1].Fields = append(foreignKeys[n-1].Fields, row.ColName)
+			foreignKeys[n-1].RefFields = append(foreignKeys[n-1].RefFields, row.RefColName)
+			continue
+		}
+		foreignKeys = append(foreignKeys, config.ForeignKeyInfo{
+			Name:      row.ConstraintName,
+			Fields:    []string{row.ColName},
+			RefTable:  row.RefTable,
+			RefFields: []string{row.RefColName},
+		})
+	}
+	return foreignKeys, nil
+}
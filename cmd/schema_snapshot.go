@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : schema_snapshot
+   @author  : 清风
+   @desc    : 表结构快照（JSON）的导出与读取
+   @time    : 2026/10/17
+*/
+
+// snapshotVersion 快照格式版本，格式不兼容时递增。版本 2 起记录了数据库驱动
+const snapshotVersion = 2
+
+// schemaSnapshot 表结构快照
+type schemaSnapshot struct {
+	Version int                 `json:"version"`
+	Driver  string              `json:"driver"` // 导出快照的数据库驱动，生成代码时据此选择数据库方言
+	Tables  []*config.TableInfo `json:"tables"`
+}
+
+// snapshotProvider 从快照文件读取表结构，无需连接数据库
+type snapshotProvider struct {
+	cfg *config.Config
+}
+
+// Tables 获取表结构信息
+func (p *snapshotProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	content, err := os.ReadFile(p.cfg.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("读取快照文件失败: %v", err)
+	}
+
+	var snapshot schemaSnapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return nil, fmt.Errorf("解析快照文件 %s 失败: %v", p.cfg.Snapshot, err)
+	}
+	switch snapshot.Version {
+	case snapshotVersion:
+	case 1:
+		// 版本 1 没有记录数据库驱动，只能由 --driver 指定
+		if p.cfg.Driver == "" {
+			return nil, fmt.Errorf("快照文件 %s 的版本为 1，没有记录数据库驱动，请通过 --driver 指定或使用当前版本重新导出", p.cfg.Snapshot)
+		}
+	default:
+		return nil, fmt.Errorf("快照文件 %s 的版本 %d 不受支持，请使用当前版本重新导出", p.cfg.Snapshot, snapshot.Version)
+	}
+	switch {
+	case p.cfg.Driver == "":
+		p.cfg.Driver = snapshot.Driver
+	case snapshot.Driver != "" && p.cfg.Driver != snapshot.Driver:
+		fmt.Printf("  警告: 快照文件由 %s 导出，将按指定的驱动 %s 生成代码\n", snapshot.Driver, p.cfg.Driver)
+	}
+
+	fmt.Printf("读取快照文件成功(%d个表)\n", len(snapshot.Tables))
+
+	if len(p.cfg.Tables) == 0 {
+		return snapshot.Tables, nil
+	}
+
+	tableMap := make(map[string]*config.TableInfo, len(snapshot.Tables))
+	for _, table := range snapshot.Tables {
+		tableMap[table.Name] = table
+	}
+
+	var tableInfos []*config.TableInfo
+	for _, tableName := range p.cfg.Tables {
+		if tableName == "" {
+			continue
+		}
+		table, ok := tableMap[tableName]
+		if !ok {
+			return nil, fmt.Errorf("表 %s 不存在", tableName)
+		}
+		tableInfos = append(tableInfos, table)
+	}
+	fmt.Printf("将生成指定的%d个表的代码\n", len(tableInfos))
+	return tableInfos, nil
+}
+
+// WriteSnapshot 将 driver 读取的表结构以快照格式写入 w
+func WriteSnapshot(w io.Writer, driver string, tables []*config.TableInfo) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(schemaSnapshot{
+		Version: snapshotVersion,
+		Driver:  driver,
+		Tables:  tables,
+	})
+}
+
+// DumpSchema 读取表结构（包含推断和配置的关联关系）并导出为快照文件
+func DumpSchema(cfg *config.Config, output string) error {
+	provider, err := NewSchemaProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	tables, err := LoadTables(context.Background(), provider, cfg)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("创建快照文件失败: %v", err)
+	}
+	if err := WriteSnapshot(file, schemaDriver(cfg), tables); err != nil {
+		file.Close()
+		return fmt.Errorf("写入快照文件失败: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("写入快照文件失败: %v", err)
+	}
+
+	fmt.Printf("导出快照: %s (%d个表)\n", output, len(tables))
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestSnapshotProviderDriver(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, "postgres", fakeTables()); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	current := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(current, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v1 := filepath.Join(t.TempDir(), "v1.json")
+	if err := os.WriteFile(v1, []byte(`{"version": 1, "tables": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v9 := filepath.Join(t.TempDir(), "v9.json")
+	if err := os.WriteFile(v9, []byte(`{"version": 9, "driver": "mysql", "tables": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		snapshot   string
+		driver     string // --driver 指定的驱动
+		wantDriver string
+		wantErr    string
+	}{
+		{name: "使用快照中的驱动", snapshot: current, wantDriver: "postgres"},
+		{name: "指定的驱动优先", snapshot: current, driver: "mysql", wantDriver: "mysql"},
+		{name: "版本 1 未指定驱动", snapshot: v1, wantErr: "没有记录数据库驱动"},
+		{name: "版本 1 指定驱动", snapshot: v1, driver: "sqlite", wantDriver: "sqlite"},
+		{name: "不支持的版本", snapshot: v9, wantErr: "版本 9 不受支持"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Snapshot: tt.snapshot, Driver: tt.driver}
+			tables, err := (&snapshotProvider{cfg: cfg}).Tables(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Tables 返回 %v，期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Tables: %v", err)
+			}
+			if cfg.Driver != tt.wantDriver {
+				t.Errorf("cfg.Driver = %q，期望 %q", cfg.Driver, tt.wantDriver)
+			}
+			if tt.snapshot == current && len(tables) != 2 {
+				t.Errorf("读取了 %d 个表，期望 2 个", len(tables))
+			}
+		})
+	}
+}
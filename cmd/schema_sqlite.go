@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : schema_sqlite
+   @author  : 清风
+   @desc    : 通过 sqlite_master 与 PRAGMA 读取 SQLite 表结构
+   @time    : 2026/10/17
+*/
+
+// sqliteProvider SQLite 表结构提供者
+type sqliteProvider struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// Tables 获取表结构信息
+func (p *sqliteProvider) Tables(ctx context.Context) ([]*config.TableInfo, error) {
+	// 连接数据库
+	db, err := gorm.Open(sqlite.Open(p.cfg.DSN), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("连接数据库失败: %v", err)
+	}
+	p.db = db.WithContext(ctx)
+
+	fmt.Println("连接数据库成功")
+
+	tableNames, err := p.tableNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var tableInfos []*config.TableInfo
+
+	// 遍历处理每个表
+	for _, tableName := range tableNames {
+		if tableName == "" {
+			continue
+		}
+
+		tableInfo, err := p.table(tableName)
+		if err != nil {
+			return nil, err
+		}
+		tableInfos = append(tableInfos, tableInfo)
+	}
+
+	return tableInfos, nil
+}
+
+// tableNames 获取需要生成的表名
+func (p *sqliteProvider) tableNames() ([]string, error) {
+	if len(p.cfg.Tables) > 0 {
+		fmt.Printf("将生成指定的%d个表的代码\n", len(p.cfg.Tables))
+		return p.cfg.Tables, nil
+	}
+
+	// 如果未指定表名，则获取所有表（排除 sqlite 内部表）
+	var tables []string
+	if err := p.db.Raw(`SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+		ORDER BY name`).Scan(&tables).Error; err != nil {
+		return nil, fmt.Errorf("获取所有表名失败: %v", err)
+	}
+	fmt.Printf("未指定表名，将生成所有表(%d个)的代码\n", len(tables))
+	return tables, nil
+}
+
+// table 获取单个表的结构信息
+func (p *sqliteProvider) table(tableName string) (*config.TableInfo, error) {
+	tableInfo := &config.TableInfo{
+		Name: tableName,
+	}
+
+	columns, err := sqliteColumns(p.db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("表 %s 不存在", tableName)
+	}
+	tableInfo.Fields = p.fields(columns)
+
+	// 主键索引（支持联合主键），pk 为列在主键中的序号（从 1 开始），0 表示不是主键
+	primaryKeys := make([]string, len(columns))
+	pkCount := 0
+	for _, col := range columns {
+		if col.PK > 0 && col.PK <= len(primaryKeys) {
+			primaryKeys[col.PK-1] = col.Name
+			pkCount++
+		}
+	}
+	if pkCount > 0 {
+		tableInfo.Indexes = append(tableInfo.Indexes, config.IndexInfo{
+			Name:   "PRIMARY",
+			Fields: primaryKeys[:pkCount],
+			IsPK:   true,
+			IsUniq: true,
+		})
+	}
+
+	indexes, err := p.indexes(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.Indexes = append(tableInfo.Indexes, indexes...)
+
+	foreignKeys, err := p.foreignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableInfo.ForeignKeys = foreignKeys
+
+	return tableInfo, nil
+}
+
+// fields 将 PRAGMA table_info 的列转换为字段信息
+func (p *sqliteProvider) fields(columns []sqliteColumn) []config.FieldInfo {
+	fields := make([]config.FieldInfo, 0, len(columns))
+	for _, col := range columns {
+		// INTEGER PRIMARY KEY 是 rowid 的别名，不可能为 NULL
+		isNullable := col.NotNull == 0 && col.PK == 0
+
+		// 处理字段类型
+		fieldType := utils.GetSQLiteGoType(col.Type)
+		if isNullable {
+			fieldType = utils.NullableType(fieldType, "pointer")
+		}
+
+		fields = append(fields, config.FieldInfo{
+			Name:       col.Name,
+			Type:       fieldType,
+			IsNullable: isNullable,
+			IsPrimary:  col.PK > 0,
+			Tag:        utils.BuildFieldTags(col.Name, col.Type, isNullable),
+			ColumnType: col.Type,
+		})
+	}
+	return fields
+}
+
+// indexes 获取表的索引信息（非主键）
+func (p *sqliteProvider) indexes(tableName string) ([]config.IndexInfo, error) {
+	var rows []struct {
+		Name   string `gorm:"column:name"`
+		Unique int    `gorm:"column:unique"`
+		Origin string `gorm:"column:origin"`
+	}
+	if err := p.db.Raw(`SELECT name, "unique", origin FROM pragma_index_list(?) ORDER BY seq DESC`, tableName).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的索引信息失败: %v", tableName, err)
+	}
+
+	var indexes []config.IndexInfo
+	for _, row := range rows {
+		// 主键已经从 table_info 中获取
+		if row.Origin == "pk" {
+			continue
+		}
+
+		var fields []string
+		if err := p.db.Raw(`SELECT COALESCE(name, '') FROM pragma_index_info(?) ORDER BY seqno`, row.Name).
+			Scan(&fields).Error; err != nil {
+			return nil, fmt.Errorf("获取索引 %s 的字段失败: %v", row.Name, err)
+		}
+		// 表达式索引中表达式部分的列名为 NULL，无法对应到字段，跳过整个索引
+		if len(fields) == 0 || containsName(fields, "") {
+			continue
+		}
+
+		indexes = append(indexes, config.IndexInfo{
+			Name:   row.Name,
+			Fields: fields,
+			IsUniq: row.Unique == 1,
+		})
+	}
+	return indexes, nil
+}
+
+// foreignKeys 获取表的外键约束，同一个 id 的多行组成一个联合外键
+func (p *sqliteProvider) foreignKeys(tableName string) ([]config.ForeignKeyInfo, error) {
+	var rows []struct {
+		ID    int     `gorm:"column:id"`
+		Table string  `gorm:"column:table"`
+		From  string  `gorm:"column:from"`
+		To    *string `gorm:"column:to"`
+	}
+	if err := p.db.Raw(`SELECT id, "table", "from", "to" FROM pragma_foreign_key_list(?) ORDER BY id, seq`, tableName).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的外键信息失败: %v", tableName, err)
+	}
+
+	fkMap := make(map[int]*config.ForeignKeyInfo)
+	var fkIDs []int
+	for _, row := range rows {
+		info, ok := fkMap[row.ID]
+		if !ok {
+			info = &config.ForeignKeyInfo{
+				Name:     fmt.Sprintf("fk_%s_%d", tableName, row.ID),
+				RefTable: row.Table,
+			}
+			fkMap[row.ID] = info
+			fkIDs = append(fkIDs, row.ID)
+		}
+		info.Fields = append(info.Fields, row.From)
+		if row.To != nil {
+			info.RefFields = append(info.RefFields, *row.To)
+		}
+	}
+
+	var foreignKeys []config.ForeignKeyInfo
+	for _, id := range fkIDs {
+		info := fkMap[id]
+		// 省略引用列时引用的是目标表的主键
+		if len(info.RefFields) == 0 {
+			refColumns, err := sqliteColumns(p.db, info.RefTable)
+			if err != nil {
+				return nil, err
+			}
+			for _, col := range refColumns {
+				if col.PK > 0 {
+					info.RefFields = append(info.RefFields, col.Name)
+				}
+			}
+		}
+		foreignKeys = append(foreignKeys, *info)
+	}
+	return foreignKeys, nil
+}
+
+// sqliteColumn PRAGMA table_info 的结果
+type sqliteColumn struct {
+	Name    string `gorm:"column:name"`
+	Type    string `gorm:"column:type"`
+	NotNull int    `gorm:"column:notnull"`
+	PK      int    `gorm:"column:pk"`
+}
+
+// sqliteColumns 获取表的列信息
+func sqliteColumns(db *gorm.DB, tableName string) ([]sqliteColumn, error) {
+	var columns []sqliteColumn
+	if err := db.Raw(`SELECT name, type, "notnull", pk FROM pragma_table_info(?) ORDER BY cid`, tableName).
+		Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("获取表 %s 的字段信息失败: %v", tableName, err)
+	}
+	for i := range columns {
+		columns[i].Type = strings.TrimSpace(columns[i].Type)
+	}
+	return columns, nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/tokmz/zero/config"
+	"gorm.io/gorm"
+)
+
+func TestSQLiteProviderTables(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "schema.db")
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	for _, sql := range []string{
+		`CREATE TABLE users (
+			id INTEGER PRIMARY KEY,
+			email VARCHAR(64) NOT NULL,
+			nickname TEXT,
+			score REAL,
+			avatar BLOB,
+			active BOOLEAN NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE UNIQUE INDEX uk_users_email ON users (email)`,
+		`CREATE TABLE tags (tenant_id INTEGER NOT NULL, name TEXT NOT NULL, PRIMARY KEY (tenant_id, name))`,
+		`CREATE TABLE posts (
+			id INTEGER PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users,
+			tenant_id INTEGER,
+			tag TEXT,
+			FOREIGN KEY (tenant_id, tag) REFERENCES tags (tenant_id, name)
+		)`,
+		`CREATE INDEX idx_posts_user ON posts (user_id)`,
+		`CREATE INDEX idx_posts_expr ON posts (lower(tag))`,
+	} {
+		if err := db.Exec(sql).Error; err != nil {
+			t.Fatalf("执行 %s 失败: %v", sql, err)
+		}
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+
+	provider := &sqliteProvider{cfg: &config.Config{DSN: dsn}}
+	tables, err := provider.Tables(context.Background())
+	if err != nil {
+		t.Fatalf("Tables: %v", err)
+	}
+	got := make(map[string]*config.TableInfo, len(tables))
+	for _, table := range tables {
+		got[table.Name] = table
+	}
+	if len(got) != 3 {
+		t.Fatalf("读取了 %d 个表，期望 3 个", len(got))
+	}
+
+	users := got["users"]
+	wantFields := []struct {
+		name, typ string
+		nullable  bool
+		primary   bool
+	}{
+		{"id", "int64", false, true},
+		{"email", "string", false, false},
+		{"nickname", "*string", true, false},
+		{"score", "*float64", true, false},
+		{"avatar", "[]byte", true, false},
+		{"active", "bool", false, false},
+		{"created_at", "time.Time", false, false},
+	}
+	if len(users.Fields) != len(wantFields) {
+		t.Fatalf("users 有 %d 个字段，期望 %d 个", len(users.Fields), len(wantFields))
+	}
+	for i, want := range wantFields {
+		field := users.Fields[i]
+		if field.Name != want.name || field.Type != want.typ || field.IsNullable != want.nullable || field.IsPrimary != want.primary {
+			t.Errorf("users 的第 %d 个字段为 %s %s nullable=%v primary=%v，期望 %+v",
+				i, field.Name, field.Type, field.IsNullable, field.IsPrimary, want)
+		}
+	}
+	wantIndexes := []config.IndexInfo{
+		{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+		{Name: "uk_users_email", Fields: []string{"email"}, IsUniq: true},
+	}
+	if !reflect.DeepEqual(users.Indexes, wantIndexes) {
+		t.Errorf("users 的索引为 %+v，期望 %+v", users.Indexes, wantIndexes)
+	}
+
+	wantIndexes = []config.IndexInfo{
+		{Name: "PRIMARY", Fields: []string{"tenant_id", "name"}, IsPK: true, IsUniq: true},
+	}
+	if !reflect.DeepEqual(got["tags"].Indexes, wantIndexes) {
+		t.Errorf("tags 的索引为 %+v，期望联合主键 %+v", got["tags"].Indexes, wantIndexes)
+	}
+
+	posts := got["posts"]
+	wantIndexes = []config.IndexInfo{
+		{Name: "PRIMARY", Fields: []string{"id"}, IsPK: true, IsUniq: true},
+		{Name: "idx_posts_user", Fields: []string{"user_id"}},
+	}
+	if !reflect.DeepEqual(posts.Indexes, wantIndexes) {
+		t.Errorf("posts 的索引为 %+v，期望 %+v（表达式索引应被跳过）", posts.Indexes, wantIndexes)
+	}
+	wantForeignKeys := []config.ForeignKeyInfo{
+		{Name: "fk_posts_0", Fields: []string{"tenant_id", "tag"}, RefTable: "tags", RefFields: []string{"tenant_id", "name"}},
+		{Name: "fk_posts_1", Fields: []string{"user_id"}, RefTable: "users", RefFields: []string{"id"}},
+	}
+	if !reflect.DeepEqual(posts.ForeignKeys, wantForeignKeys) {
+		t.Errorf("posts 的外键为 %+v，期望 %+v", posts.ForeignKeys, wantForeignKeys)
+	}
+}
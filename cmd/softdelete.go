@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : softdelete
+   @author  : 清风
+   @desc    : 识别软删除列（soft_delete 配置），使用 gorm.DeletedAt 或 soft_delete.DeletedAt
+   @time    : 2026/10/17
+*/
+
+// softDeleteImport 软删除插件的导入路径
+const softDeleteImport = "gorm.io/plugin/soft_delete"
+
+var (
+	// defaultSoftDeleteColumns 默认的软删除时间列
+	defaultSoftDeleteColumns = []string{"deleted_at"}
+	// defaultSoftDeleteFlags 默认的软删除标记列
+	defaultSoftDeleteFlags = []string{"is_deleted"}
+)
+
+// applySoftDelete 按 soft_delete 配置识别每个表的软删除列并调整字段类型，需要在 applyTypes 之后执行：
+//   - 标记列（整数或 bool）使用 soft_delete.DeletedAt 并加上 softDelete:flag，
+//     表中同时有软删除时间列时，时间列保持原类型，删除时由插件一并写入（DeletedAtField）
+//   - 时间列为时间类型时使用 gorm.DeletedAt，为整数类型时按 Unix 时间戳使用 soft_delete.DeletedAt
+//
+// 查询方法的参数类型（ValueType）保持不变
+func applySoftDelete(tables []*config.TableInfo, cfg *config.Config) {
+	columns, flags := cfg.SoftDelete.Columns, cfg.SoftDelete.Flags
+	if columns == nil {
+		columns = defaultSoftDeleteColumns
+	}
+	if flags == nil {
+		flags = defaultSoftDeleteFlags
+	}
+
+	for _, table := range tables {
+		table.SoftDelete = nil
+		var timeField, flagField *config.FieldInfo
+		for i := range table.Fields {
+			field := &table.Fields[i]
+			field.GormOptions = ""
+			switch {
+			case flagField == nil && matchColumn(flags, table.Name, field.Name) && (isIntegerType(field.ValueType) || field.ValueType == "bool"):
+				flagField = field
+			case timeField == nil && matchColumn(columns, table.Name, field.Name) && (field.ValueType == "time.Time" || isIntegerType(field.ValueType)):
+				timeField = field
+			}
+		}
+
+		switch {
+		case flagField != nil:
+			flagField.Type = "soft_delete.DeletedAt"
+			flagField.Imports = []string{softDeleteImport}
+			flagField.GormOptions = "softDelete:flag"
+			if timeField != nil {
+				flagField.GormOptions += ",DeletedAtField:" + timeField.GoName
+			}
+			table.SoftDelete = &config.SoftDeleteInfo{Column: flagField.Name, Kind: "flag", DeletedAt: timeField}
+		case timeField != nil && timeField.ValueType == "time.Time":
+			timeField.Type = "gorm.DeletedAt"
+			timeField.Imports = []string{"gorm.io/gorm"}
+			table.SoftDelete = &config.SoftDeleteInfo{Column: timeField.Name, Kind: "time"}
+		case timeField != nil:
+			timeField.Type = "soft_delete.DeletedAt"
+			timeField.Imports = []string{softDeleteImport}
+			table.SoftDelete = &config.SoftDeleteInfo{Column: timeField.Name, Kind: "unix"}
+		}
+	}
+}
+
+// restoreColumn Restore 恢复记录时更新的列
+type restoreColumn struct {
+	Column string // 列名
+	Value  string // 恢复后的值，如 nil、0
+}
+
+// buildRestore 返回 Restore 需要更新的列：软删除列恢复为未删除，标记列同时记录了删除时间时一并清空删除时间。
+// MySQL 的多表 UPDATE 中列名可能有歧义，列名前加上表名；PostgreSQL、SQLite 的 SET 中不能带表名
+func buildRestore(table *config.TableInfo, cfg *config.Config) []restoreColumn {
+	info := table.SoftDelete
+	if info == nil {
+		return nil
+	}
+	prefix := ""
+	if isMySQL(cfg) {
+		prefix = table.Name + "."
+	}
+
+	value := "0"
+	if info.Kind == "time" {
+		value = "nil"
+	}
+	columns := []restoreColumn{{Column: prefix + info.Column, Value: value}}
+
+	if field := info.DeletedAt; field != nil {
+		switch {
+		case field.IsNullable:
+			value = "nil"
+		case isIntegerType(field.ValueType):
+			value = "0"
+		default:
+			value = field.ValueType + "{}"
+		}
+		columns = append(columns, restoreColumn{Column: prefix + field.Name, Value: value})
+	}
+	return columns
+}
+
+// matchColumn 判断列是否在列表中，列表项可以是 列名 或 表名.列名（不区分大小写）
+func matchColumn(list []string, table, column string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, column) || strings.EqualFold(item, table+"."+column) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIntegerType 判断是否为整数类型
+func isIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestBuildRestore(t *testing.T) {
+	flags := func() *config.TableInfo {
+		return &config.TableInfo{
+			Name: "flags",
+			Fields: []config.FieldInfo{
+				{Name: "id", Type: "int64", IsPrimary: true},
+				{Name: "is_deleted", Type: "bool"},
+				{Name: "deleted_at", Type: "*time.Time", IsNullable: true},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		cfg    *config.Config
+		tables []*config.TableInfo
+		want   []restoreColumn
+	}{
+		{
+			name:   "标记列与删除时间一并恢复",
+			cfg:    &config.Config{Driver: "sqlite"},
+			tables: []*config.TableInfo{flags()},
+			want:   []restoreColumn{{Column: "is_deleted", Value: "0"}, {Column: "deleted_at", Value: "nil"}},
+		},
+		{
+			name:   "MySQL 列名带表名",
+			cfg:    &config.Config{Driver: "mysql"},
+			tables: []*config.TableInfo{flags()},
+			want:   []restoreColumn{{Column: "flags.is_deleted", Value: "0"}, {Column: "flags.deleted_at", Value: "nil"}},
+		},
+		{
+			name: "时间列",
+			cfg:  &config.Config{Driver: "postgres"},
+			tables: []*config.TableInfo{{
+				Name:   "posts",
+				Fields: []config.FieldInfo{{Name: "deleted_at", Type: "*time.Time", IsNullable: true}},
+			}},
+			want: []restoreColumn{{Column: "deleted_at", Value: "nil"}},
+		},
+	}
+	for _, tt := range tests {
+		if err := applyTypes(tt.tables, tt.cfg); err != nil {
+			t.Fatalf("%s: applyTypes: %v", tt.name, err)
+		}
+		applySoftDelete(tt.tables, tt.cfg)
+		if got := buildRestore(tt.tables[0], tt.cfg); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: buildRestore = %+v，期望 %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+	"github.com/tokmz/zero/utils"
+)
+
+/*
+   @NAME    : types
+   @author  : 清风
+   @desc    : 按配置的类型映射（types、columns）确定字段的 Go 类型及导入
+   @time    : 2026/10/17
+*/
+
+// typeRule 类型映射规则
+type typeRule struct {
+	pattern    string
+	goType     string
+	importPath string
+}
+
+// applyTypes 按配置确定字段的 Go 类型：json 中绑定了类型的列使用 orm 包中的 JSON[T]，
+// 其次匹配 columns 中的 表名.列名，再按优先级匹配 types 中的类型模式，
+// 都未匹配时 ENUM/SET 列使用生成的枚举类型，其余保留表结构提供者的默认类型。
+// 可为空的列按 nullable 配置包装为 *T、sql.NullString 或 sql.Null[T]，同时记录每个字段类型需要导入的包
+func applyTypes(tables []*config.TableInfo, cfg *config.Config) error {
+	rules, err := buildTypeRules(cfg.Types)
+	if err != nil {
+		return err
+	}
+
+	columns := make(map[string]typeRule, len(cfg.Columns))
+	for key, spec := range cfg.Columns {
+		goType, importPath, err := utils.ParseGoType(spec)
+		if err != nil {
+			return fmt.Errorf("列 %s 的类型配置错误: %v", key, err)
+		}
+		columns[strings.ToLower(key)] = typeRule{pattern: key, goType: goType, importPath: importPath}
+	}
+
+	jsonRules, err := buildJSONRules(cfg)
+	if err != nil {
+		return err
+	}
+
+	namer := newNamer(cfg)
+
+	// 模型与枚举类型在同一个包中，类型名不能重复
+	taken := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		taken[table.ModelName] = true
+	}
+
+	for _, table := range tables {
+		for i := range table.Fields {
+			field := &table.Fields[i]
+			field.Enum = nil
+			field.JSON = false
+			field.Numeric = false
+
+			// 表结构提供者为可为空的列加了指针，先还原为非空值的类型
+			valueType := field.Type
+			if field.IsNullable {
+				valueType = strings.TrimPrefix(valueType, "*")
+			}
+			imports := []string{utils.TypeImport(valueType)}
+			nullable := field.IsNullable
+
+			key := strings.ToLower(table.Name + "." + field.Name)
+			if rule, ok := jsonRules[key]; ok {
+				field.JSON = true
+				field.ValueType = rule.goType
+				field.ValueImports = rule.imports
+				field.Type = rule.goType
+				field.Imports = rule.imports
+				// sql.Null[T] 的 Value 不会调用 JSON[T] 的 Value，可为空的 JSON 列统一使用指针
+				if nullable {
+					field.Type = "*" + rule.goType
+				}
+				continue
+			}
+
+			rule, ok := columns[key]
+			if !ok {
+				for _, r := range rules {
+					if utils.MatchColumnType(r.pattern, field.ColumnType) {
+						rule, ok = r, true
+						break
+					}
+				}
+			}
+
+			if ok {
+				valueType, imports = rule.goType, []string{rule.importPath}
+			} else if enum := buildEnum(table, field, taken, namer); enum != nil {
+				// ENUM/SET 列使用生成的枚举类型，SET 列的切片类型本身可以表示 NULL
+				field.Enum = enum
+				valueType, imports = enum.Type, nil
+				if enum.SetType != "" {
+					valueType, nullable = enum.SetType, false
+				}
+			}
+
+			field.ValueType = valueType
+			field.ValueImports = compactImports(imports)
+			field.Numeric = isNumericType(valueType)
+			field.Type = valueType
+			field.Imports = field.ValueImports
+			if nullable {
+				field.Type = utils.NullableType(valueType, cfg.Nullable)
+				// sql.NullTime 等类型不再引用非空值类型的包
+				if !strings.Contains(field.Type, valueType) {
+					imports = nil
+				}
+				field.Imports = compactImports(append(imports, utils.TypeImport(field.Type)))
+			}
+		}
+	}
+	return nil
+}
+
+// isNumericType 判断是否为整数或浮点数类型，映射的自定义类型（如 decimal.Decimal）不视为数字
+func isNumericType(goType string) bool {
+	return isIntegerType(goType) || goType == "float32" || goType == "float64"
+}
+
+// buildTypeRules 解析 types 配置，按模式的优先级排序（同优先级时模式越长越优先）
+func buildTypeRules(types map[string]string) ([]typeRule, error) {
+	rules := make([]typeRule, 0, len(types))
+	for pattern, spec := range types {
+		goType, importPath, err := utils.ParseGoType(spec)
+		if err != nil {
+			return nil, fmt.Errorf("类型 %s 的映射配置错误: %v", pattern, err)
+		}
+		rules = append(rules, typeRule{pattern: pattern, goType: goType, importPath: importPath})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		si, sj := utils.PatternSpecificity(rules[i].pattern), utils.PatternSpecificity(rules[j].pattern)
+		if si != sj {
+			return si > sj
+		}
+		if len(rules[i].pattern) != len(rules[j].pattern) {
+			return len(rules[i].pattern) > len(rules[j].pattern)
+		}
+		return rules[i].pattern < rules[j].pattern
+	})
+	return rules, nil
+}
+
+// fieldImports 返回字段类型（model 中的字段类型）实际用到的包，分为标准库和第三方库，exclude 中的包已由模板导入
+func fieldImports(fields []config.FieldInfo, exclude ...string) (std, third []string) {
+	var paths []string
+	for _, field := range fields {
+		paths = append(paths, field.Imports...)
+	}
+	return groupImports(paths, exclude...)
+}
+
+// valueImports 返回字段非空值类型（query 中的参数类型）实际用到的包，分为标准库和第三方库，exclude 中的包已由模板导入
+func valueImports(fields []config.FieldInfo, exclude ...string) (std, third []string) {
+	var paths []string
+	for _, field := range fields {
+		paths = append(paths, field.ValueImports...)
+	}
+	return groupImports(paths, exclude...)
+}
+
+// groupImports 将导入路径去重后分为标准库和第三方库并各自排序
+func groupImports(paths []string, exclude ...string) (std, third []string) {
+	seen := make(map[string]bool)
+	for _, path := range exclude {
+		seen[path] = true
+	}
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		// 标准库的导入路径第一段不含点号
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			third = append(third, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(third)
+	return std, third
+}
+
+// compactImports 去掉空的和重复的导入路径
+func compactImports(paths []string) []string {
+	var result []string
+	for _, path := range paths {
+		if path != "" && !containsName(result, path) {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+// mergeImports 合并导入路径，去重并排序
+func mergeImports(imports []string, paths ...string) []string {
+	for _, path := range paths {
+		if !containsName(imports, path) {
+			imports = append(imports, path)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+func TestApplyTypes(t *testing.T) {
+	table := &config.TableInfo{
+		Name:      "orders",
+		ModelName: "Orders",
+		Fields: []config.FieldInfo{
+			{Name: "id", GoName: "ID", Type: "uint64", ColumnType: "bigint(20) unsigned"},
+			{Name: "amount", GoName: "Amount", Type: "float64", ColumnType: "decimal(10,2)"},
+			{Name: "price", GoName: "Price", Type: "float64", ColumnType: "decimal(12,4)"},
+			{Name: "hits", GoName: "Hits", Type: "*uint64", ColumnType: "bigint(20) unsigned", IsNullable: true},
+			{Name: "paid", GoName: "Paid", Type: "bool", ColumnType: "tinyint(1)"},
+			{Name: "weight", GoName: "Weight", Type: "float64", ColumnType: "double"},
+			{Name: "location", GoName: "Location", Type: "int", ColumnType: "int(11)"},
+			{Name: "note", GoName: "Note", Type: "string", ColumnType: "varchar(255)"},
+			{Name: "status", GoName: "Status", Type: "string", ColumnType: "enum('a','b')"},
+			{Name: "level", GoName: "Level", Type: "string", ColumnType: "enum('lo','hi')"},
+			{Name: "kind", GoName: "Kind", Type: "string", ColumnType: "enum('x','y')"},
+			{Name: "meta", GoName: "Meta", Type: "*json.RawMessage", ColumnType: "json", IsNullable: true},
+			{Name: "created_at", GoName: "CreatedAt", Type: "time.Time", ColumnType: "datetime"},
+		},
+	}
+	cfg := &config.Config{
+		ModuleName: "example.com/app",
+		Output:     config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model"},
+		Types: map[string]string{
+			"bigint unsigned": "int64",
+			"decimal(*,*)":    "github.com/shopspring/decimal.Decimal",
+			"decimal(10,2)":   "example.com/app/money.Cents",
+			"varchar(*)":      "example.com/app/text.Rich",
+			"enum('lo','hi')": "string",
+			"json":            "string",
+		},
+		Columns: map[string]string{
+			"orders.location": "github.com/foo/geo.Point",
+			"orders.note":     "string",
+			"Orders.Status":   "string",
+			"orders.meta":     "string",
+		},
+		JSON: map[string]string{
+			"orders.meta": "pkg/settings.Meta",
+		},
+	}
+	if err := applyTypes([]*config.TableInfo{table}, cfg); err != nil {
+		t.Fatalf("applyTypes: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		typ     string
+		imports []string
+		numeric bool
+		enum    bool
+	}{
+		// 不带括号的模式匹配带参数的列类型
+		{name: "id", typ: "int64", numeric: true},
+		// 确定参数的模式优先于通配符
+		{name: "amount", typ: "money.Cents", imports: []string{"example.com/app/money"}},
+		{name: "price", typ: "decimal.Decimal", imports: []string{"github.com/shopspring/decimal"}},
+		// 可为空的列先还原为非空值类型再匹配
+		{name: "hits", typ: "*int64", numeric: true},
+		{name: "paid", typ: "bool"},
+		{name: "weight", typ: "float64", numeric: true},
+		// 整数列映射为自定义类型时不生成范围查询
+		{name: "location", typ: "geo.Point", imports: []string{"github.com/foo/geo"}},
+		// columns 优先于 types，键不区分大小写
+		{name: "note", typ: "string"},
+		// columns、types 优先于枚举
+		{name: "status", typ: "string"},
+		{name: "level", typ: "string"},
+		{name: "kind", typ: "OrdersKind", enum: true},
+		// json 优先于 columns 和 types，可为空时使用指针
+		{name: "meta", typ: "*orm.JSON[settings.Meta]", imports: []string{"example.com/app/orm", "example.com/app/pkg/settings"}},
+		{name: "created_at", typ: "time.Time", imports: []string{"time"}},
+	}
+	for i, tt := range tests {
+		field := table.Fields[i]
+		if field.Name != tt.name {
+			t.Fatalf("第 %d 个字段为 %s，期望 %s", i, field.Name, tt.name)
+		}
+		if field.Type != tt.typ || !reflect.DeepEqual(field.Imports, tt.imports) ||
+			field.Numeric != tt.numeric || (field.Enum != nil) != tt.enum {
+			t.Errorf("%s: Type=%q Imports=%q Numeric=%v Enum=%v，期望 %+v",
+				tt.name, field.Type, field.Imports, field.Numeric, field.Enum != nil, tt)
+		}
+	}
+}
+
+func TestApplyTypesInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+	}{
+		{"types 中的类型无效", config.Config{Types: map[string]string{"decimal": "map[string]int"}}},
+		{"columns 中的类型无效", config.Config{Columns: map[string]string{"orders.amount": "decimal."}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := applyTypes(nil, &tt.cfg); err == nil {
+				t.Error("applyTypes 没有返回错误")
+			}
+		})
+	}
+}
+
+func TestRangeHelpersOnlyForNumericFields(t *testing.T) {
+	cfg := &config.Config{
+		ModuleName: "example.com/app",
+		Output:     config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model", QueryDir: "orm/query"},
+		Columns:    map[string]string{"posts.user_id": "example.com/app/geo.Interval"},
+	}
+	tables, err := LoadTables(context.Background(), &fakeProvider{tables: fakeTables()}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	out := newMemoryOutput()
+	if err := GenerateFiles(tables, cfg, out); err != nil {
+		t.Fatalf("GenerateFiles: %v", err)
+	}
+	content := string(out.files[filepath.Join("orm", "query", "posts.go")])
+	if !strings.Contains(content, "func (q *PostsQuery) WhereIDGT(") {
+		t.Error("整数字段 id 没有生成 WhereIDGT")
+	}
+	if strings.Contains(content, "WhereUserIDGT") || strings.Contains(content, "WhereUserIDBetween") {
+		t.Error("映射为 geo.Interval 的 user_id 不应生成范围查询")
+	}
+}
+
+func TestApplyTypesNullable(t *testing.T) {
+	fields := func() []config.FieldInfo {
+		return []config.FieldInfo{
+			{Name: "name", GoName: "Name", Type: "*string", ColumnType: "varchar(32)", IsNullable: true},
+			{Name: "age", GoName: "Age", Type: "*int32", ColumnType: "int", IsNullable: true},
+			{Name: "hits", GoName: "Hits", Type: "*uint64", ColumnType: "bigint unsigned", IsNullable: true},
+			{Name: "paid_at", GoName: "PaidAt", Type: "*time.Time", ColumnType: "datetime", IsNullable: true},
+			{Name: "price", GoName: "Price", Type: "*float64", ColumnType: "decimal(10,2)", IsNullable: true},
+			{Name: "avatar", GoName: "Avatar", Type: "[]byte", ColumnType: "blob", IsNullable: true},
+			{Name: "meta", GoName: "Meta", Type: "json.RawMessage", ColumnType: "json", IsNullable: true},
+			{Name: "status", GoName: "Status", Type: "*string", ColumnType: "enum('a','b')", IsNullable: true},
+			{Name: "tags", GoName: "Tags", Type: "*string", ColumnType: "set('x','y')", IsNullable: true},
+			{Name: "title", GoName: "Title", Type: "string", ColumnType: "varchar(32)"},
+		}
+	}
+
+	type want struct {
+		typ     string
+		imports []string
+	}
+	tests := []struct {
+		strategy string
+		want     []want
+	}{
+		{"", []want{
+			{"*string", nil},
+			{"*int32", nil},
+			{"*uint64", nil},
+			{"*time.Time", []string{"time"}},
+			{"*decimal.Decimal", []string{"github.com/shopspring/decimal"}},
+			{"[]byte", nil},
+			{"json.RawMessage", []string{"encoding/json"}},
+			{"*OrdersStatus", nil},
+			{"OrdersTags", nil},
+			{"string", nil},
+		}},
+		{"sql_null", []want{
+			{"sql.NullString", []string{"database/sql"}},
+			{"sql.NullInt32", []string{"database/sql"}},
+			{"sql.Null[uint64]", []string{"database/sql"}},
+			{"sql.NullTime", []string{"database/sql"}},
+			{"sql.Null[decimal.Decimal]", []string{"github.com/shopspring/decimal", "database/sql"}},
+			{"[]byte", nil},
+			{"json.RawMessage", []string{"encoding/json"}},
+			{"sql.Null[OrdersStatus]", []string{"database/sql"}},
+			{"OrdersTags", nil},
+			{"string", nil},
+		}},
+		{"generic", []want{
+			{"sql.Null[string]", []string{"database/sql"}},
+			{"sql.Null[int32]", []string{"database/sql"}},
+			{"sql.Null[uint64]", []string{"database/sql"}},
+			{"sql.Null[time.Time]", []string{"time", "database/sql"}},
+			{"sql.Null[decimal.Decimal]", []string{"github.com/shopspring/decimal", "database/sql"}},
+			{"[]byte", nil},
+			{"json.RawMessage", []string{"encoding/json"}},
+			{"sql.Null[OrdersStatus]", []string{"database/sql"}},
+			{"OrdersTags", nil},
+			{"string", nil},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			table := &config.TableInfo{Name: "orders", ModelName: "Orders", Fields: fields()}
+			cfg := &config.Config{
+				Nullable: tt.strategy,
+				Types:    map[string]string{"decimal(*,*)": "github.com/shopspring/decimal.Decimal"},
+			}
+			if err := applyTypes([]*config.TableInfo{table}, cfg); err != nil {
+				t.Fatalf("applyTypes: %v", err)
+			}
+			for i, want := range tt.want {
+				field := table.Fields[i]
+				if field.Type != want.typ || !reflect.DeepEqual(field.Imports, want.imports) {
+					t.Errorf("%s: Type=%q Imports=%q，期望 %q %q", field.Name, field.Type, field.Imports, want.typ, want.imports)
+				}
+				// 查询方法的参数使用非空值的类型
+				if field.Name == "paid_at" && (field.ValueType != "time.Time" || !reflect.DeepEqual(field.ValueImports, []string{"time"})) {
+					t.Errorf("paid_at: ValueType=%q ValueImports=%q，期望 time.Time", field.ValueType, field.ValueImports)
+				}
+			}
+		})
+	}
+}
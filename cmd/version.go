@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tokmz/zero/config"
+)
+
+/*
+   @NAME    : version
+   @author  : 清风
+   @desc    : 乐观锁版本号列（version 配置），使用 optimisticlock.Version
+   @time    : 2026/10/17
+*/
+
+// optimisticLockImport 乐观锁插件的导入路径
+const optimisticLockImport = "gorm.io/plugin/optimisticlock"
+
+// applyVersion 按 version 配置识别每个表的乐观锁版本号列，每个表最多一个，需要在 applyTypes 之后执行。
+// 版本号列只能是整数类型；以 表名.列名 配置的列在表中不存在时返回错误。查询方法的参数类型（ValueType）保持不变
+func applyVersion(tables []*config.TableInfo, cfg *config.Config) error {
+	for _, table := range tables {
+		table.Version = nil
+		for _, item := range cfg.Version {
+			if tableName, column, ok := strings.Cut(item, "."); ok && strings.EqualFold(tableName, table.Name) && findField(table, column) == nil {
+				return fmt.Errorf("表 %s 的乐观锁版本号列 %s 不存在", table.Name, column)
+			}
+		}
+		for i := range table.Fields {
+			field := &table.Fields[i]
+			if !matchColumn(cfg.Version, table.Name, field.Name) {
+				continue
+			}
+			if !isIntegerType(field.ValueType) {
+				return fmt.Errorf("表 %s 的乐观锁版本号列 %s 的类型为 %s，只能使用整数类型的列（可以用 表名.列名 只指定部分表）",
+					table.Name, field.Name, field.ValueType)
+			}
+			field.Type = "optimisticlock.Version"
+			field.Imports = []string{optimisticLockImport}
+			table.Version = field
+			break
+		}
+	}
+	return nil
+}
+
+// findField 按列名查找字段（不区分大小写）
+func findField(table *config.TableInfo, column string) *config.FieldInfo {
+	for i := range table.Fields {
+		if strings.EqualFold(table.Fields[i].Name, column) {
+			return &table.Fields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tokmz/zero/config"
+)
+
+// versionTables 返回测试乐观锁的表结构
+func versionTables() []*config.TableInfo {
+	return []*config.TableInfo{
+		{
+			Name: "users",
+			Fields: []config.FieldInfo{
+				{Name: "id", GoName: "ID", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+				{Name: "name", GoName: "Name", Type: "string", ColumnType: "varchar(32)"},
+				{Name: "version", GoName: "Version", Type: "int64", ColumnType: "bigint"},
+			},
+		},
+		{
+			Name: "posts",
+			Fields: []config.FieldInfo{
+				{Name: "id", GoName: "ID", Type: "int64", ColumnType: "bigint", IsPrimary: true},
+				{Name: "revision", GoName: "Revision", Type: "*uint32", ColumnType: "int unsigned", IsNullable: true},
+			},
+		},
+	}
+}
+
+func TestApplyVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version []string
+		want    map[string]string // 表名 -> 版本号列
+	}{
+		{"列名匹配所有表", []string{"version"}, map[string]string{"users": "version"}},
+		{"表名.列名，可为空的列", []string{"POSTS.Revision"}, map[string]string{"posts": "revision"}},
+		{"多个配置", []string{"version", "posts.revision"}, map[string]string{"users": "version", "posts": "revision"}},
+		// 其他表的配置不影响这些表
+		{"配置的表不在本次生成中", []string{"orders.version"}, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tables := versionTables()
+			cfg := &config.Config{Version: tt.version}
+			if err := applyTypes(tables, cfg); err != nil {
+				t.Fatalf("applyTypes: %v", err)
+			}
+			if err := applyVersion(tables, cfg); err != nil {
+				t.Fatalf("applyVersion: %v", err)
+			}
+			got := map[string]string{}
+			for _, table := range tables {
+				if table.Version == nil {
+					continue
+				}
+				got[table.Name] = table.Version.Name
+				field := table.Version
+				if field.Type != "optimisticlock.Version" || !reflect.DeepEqual(field.Imports, []string{optimisticLockImport}) {
+					t.Errorf("%s.%s: Type=%q Imports=%q，期望 optimisticlock.Version", table.Name, field.Name, field.Type, field.Imports)
+				}
+				// 查询方法的参数仍使用整数类型
+				if want := map[string]string{"users": "int64", "posts": "uint32"}[table.Name]; field.ValueType != want {
+					t.Errorf("%s.%s: ValueType=%q，期望 %q", table.Name, field.Name, field.ValueType, want)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("版本号列为 %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyVersionErrors(t *testing.T) {
+	tests := []struct {
+		version []string
+		want    string
+	}{
+		{[]string{"users.missing"}, "表 users 的乐观锁版本号列 missing 不存在"},
+		{[]string{"users.name"}, "表 users 的乐观锁版本号列 name 的类型为 string，只能使用整数类型的列"},
+		{[]string{"name"}, "表 users 的乐观锁版本号列 name 的类型为 string"},
+	}
+	for _, tt := range tests {
+		tables := versionTables()
+		cfg := &config.Config{Version: tt.version}
+		if err := applyTypes(tables, cfg); err != nil {
+			t.Fatalf("applyTypes: %v", err)
+		}
+		if err := applyVersion(tables, cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("version 为 %v 时 applyVersion 返回 %v，期望包含 %q", tt.version, err, tt.want)
+		}
+	}
+}
+
+func TestGenerateFilesWithVersion(t *testing.T) {
+	cfg := &config.Config{
+		ModuleName: "example.com/app",
+		Output:     config.OutputConfig{OrmDir: "orm", ModelDir: "orm/model", QueryDir: "orm/query"},
+		Version:    []string{"version"},
+	}
+	tables, err := LoadTables(context.Background(), &fakeProvider{tables: versionTables()}, cfg)
+	if err != nil {
+		t.Fatalf("LoadTables: %v", err)
+	}
+	out := newMemoryOutput()
+	if err := GenerateFiles(tables, cfg, out); err != nil {
+		t.Fatalf("GenerateFiles: %v", err)
+	}
+
+	model := string(out.files[filepath.Join("orm", "model", "users.go")])
+	for _, want := range []string{`"gorm.io/plugin/optimisticlock"`, "Version optimisticlock.Version"} {
+		if !strings.Contains(model, want) {
+			t.Errorf("users 模型中缺少 %q", want)
+		}
+	}
+	query := string(out.files[filepath.Join("orm", "query", "users.go")])
+	for _, want := range []string{
+		"func (q *UsersQuery) UpdateWithVersion(data *model.Users, values interface{}) error",
+		"Version: data.Version.Int64}",
+		// 查询方法的参数仍为整数
+		"func (q *UsersQuery) WhereVersion(value int64)",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("users 查询中缺少 %q", want)
+		}
+	}
+	if strings.Contains(string(out.files[filepath.Join("orm", "query", "posts.go")]), "UpdateWithVersion") {
+		t.Error("没有版本号列的 posts 不应生成 UpdateWithVersion")
+	}
+}
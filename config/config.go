@@ -3,12 +3,25 @@ package config
 // Config 配置结构体
 type Config struct {
 	DSN           string                `yaml:"dsn"`
+	Driver        string                `yaml:"driver"` // 数据库驱动: mysql, postgres, sqlite
+	DDL           string                `yaml:"ddl"`    // MySQL DDL 文件路径，支持 glob，指定后不再连接数据库
+	Snapshot      string                `yaml:"-"`      // 表结构快照文件路径（--from-snapshot），指定后不再连接数据库
 	Output        OutputConfig          `yaml:"output"`
 	Tables        []string              `yaml:"tables"`
-	Prefix        string                `yaml:"prefix"`
-	Style         string                `yaml:"style"`
+	Prefix        []string              `yaml:"prefix"` // 表名前缀，生成类型名和文件名时去除
+	Suffix        []string              `yaml:"suffix"` // 表名后缀，生成类型名和文件名时去除
+	Style         string                `yaml:"style"`  // 文件命名风格: snake, camel, pascal, kebab, lower
 	Template      string                `yaml:"template"`
 	Relations     map[string][]Relation `yaml:"relations"`
+	RelationInfer string                `yaml:"-"`                                      // 关联关系推断方式（relations.infer）: fk(默认，按外键约束), naming(外键约束+命名约定), none(不推断)
+	Nullable      string                `yaml:"nullable"`                               // 可为空列的类型: pointer(默认，*T), sql_null(sql.NullString 等), generic(sql.Null[T])
+	Types         map[string]string     `yaml:"types"`                                  // 数据库类型到 Go 类型的映射，如 decimal(*,*): github.com/shopspring/decimal.Decimal
+	Columns       map[string]string     `yaml:"columns"`                                // 指定列的 Go 类型，键为 表名.列名，优先于 types
+	JSON          map[string]string     `yaml:"json"`                                   // JSON 列绑定的 Go 类型，键为 表名.列名，字段类型为 orm 包中的 JSON[T]
+	Naming        NamingConfig          `yaml:"naming"`                                 // 表名、列名到 Go 标识符的命名配置
+	SoftDelete    SoftDeleteConfig      `yaml:"soft_delete" mapstructure:"soft_delete"` // 软删除列配置
+	Version       []string              `yaml:"version"`                                // 乐观锁版本号列，可以写 列名（所有表）或 表名.列名，字段类型为 optimisticlock.Version
+	Cursor        map[string][]string   `yaml:"cursor"`                                 // 游标分页的排序列，键为表名，列名后可加 desc 表示降序，如 orders: [created_at desc, id desc]，不唯一时追加主键，未配置时使用主键
 	ModuleName    string                `yaml:"module_name" mapstructure:"module_name"`
 	EnableTracing bool                  `yaml:"enable_tracing" mapstructure:"enable_tracing"` // 是否启用链路追踪
 }
@@ -20,6 +33,19 @@ type OutputConfig struct {
 	QueryDir string `yaml:"query_dir"` // query代码生成目录
 }
 
+// NamingConfig 命名配置
+type NamingConfig struct {
+	Dictionary map[string]string `yaml:"dictionary"` // 单词在 Go 标识符中的写法，如 sku: SKU、oauth: OAuth，优先于内置的常见缩写
+	Tables     map[string]string `yaml:"tables"`     // 指定表的模型名，如 user_info: Account
+	Columns    map[string]string `yaml:"columns"`    // 指定列的字段名，键为 表名.列名，如 users.uid: UserID
+}
+
+// SoftDeleteConfig 软删除列配置，列名可以写 列名 或 表名.列名
+type SoftDeleteConfig struct {
+	Columns []string `yaml:"columns"` // 软删除时间列，默认 deleted_at：时间类型使用 gorm.DeletedAt，整数类型（Unix 时间戳）使用 soft_delete.DeletedAt
+	Flags   []string `yaml:"flags"`   // 软删除标记列，默认 is_deleted：使用 soft_delete.DeletedAt（softDelete:flag）
+}
+
 // Relation 表关联关系配置
 type Relation struct {
 	Target         string `yaml:"target"`                                           // 目标表
@@ -34,25 +60,46 @@ type Relation struct {
 
 // TableInfo 表信息
 type TableInfo struct {
-	Name      string         // 表名
-	Comment   string         // 表注释
-	Fields    []FieldInfo    // 字段列表
-	Indexes   []IndexInfo    // 索引列表
-	Relations []RelationInfo // 关联关系
-	Package   string         // 包名
+	Name        string           `json:"name"`                   // 表名
+	Comment     string           `json:"comment,omitempty"`      // 表注释
+	Fields      []FieldInfo      `json:"fields"`                 // 字段列表
+	Indexes     []IndexInfo      `json:"indexes,omitempty"`      // 索引列表
+	ForeignKeys []ForeignKeyInfo `json:"foreign_keys,omitempty"` // 外键约束
+	Relations   []RelationInfo   `json:"relations,omitempty"`    // 关联关系
+	Package     string           `json:"package,omitempty"`      // 包名
+	BaseName    string           `json:"-"`                      // 去除前缀、后缀后的表名，用于生成文件名
+	ModelName   string           `json:"-"`                      // 模型的 Go 类型名
+	SoftDelete  *SoftDeleteInfo  `json:"-"`                      // 软删除列，没有时为 nil
+	Version     *FieldInfo       `json:"-"`                      // 乐观锁版本号字段，没有时为 nil
+	Cursor      []CursorColumn   `json:"-"`                      // 游标分页的排序列，没有时不生成游标分页方法
+}
+
+// CursorColumn 游标分页的排序列
+type CursorColumn struct {
+	Field *FieldInfo // 排序字段
+	Desc  bool       // 是否降序
+}
+
+// SoftDeleteInfo 表的软删除列
+type SoftDeleteInfo struct {
+	Column    string     // 软删除列名
+	Kind      string     // 软删除方式: time(gorm.DeletedAt), unix(Unix 时间戳), flag(0/1 标记)
+	DeletedAt *FieldInfo // 标记列删除时同时写入的删除时间列（DeletedAtField），没有时为 nil
 }
 
 // RelationInfo 关联关系信息
 type RelationInfo struct {
-	Name           string // 关联名称
-	Type           string // 关联类型: HasOne, HasMany, BelongsTo, ManyToMany
-	Model          string // 关联模型名称
-	ForeignKey     string // 外键
-	References     string // 引用键
-	JoinTable      string // 连接表（多对多关系）
-	JoinForeignKey string // 连接表外键（多对多关系）
-	JoinReferences string // 连接表引用键（多对多关系）
-	Comment        string // 关联关系注释
+	Name           string `json:"name"`                       // 关联名称
+	Type           string `json:"type"`                       // 关联类型: HasOne, HasMany, BelongsTo, ManyToMany
+	Model          string `json:"model"`                      // 关联模型名称
+	ForeignKey     string `json:"foreign_key,omitempty"`      // 外键
+	References     string `json:"references,omitempty"`       // 引用键
+	JoinTable      string `json:"join_table,omitempty"`       // 连接表（多对多关系）
+	JoinForeignKey string `json:"join_foreign_key,omitempty"` // 连接表外键（多对多关系）
+	JoinReferences string `json:"join_references,omitempty"`  // 连接表引用键（多对多关系）
+	Comment        string `json:"comment,omitempty"`          // 关联关系注释
+	GoName         string `json:"-"`                          // 关联字段的 Go 名称
+	ModelName      string `json:"-"`                          // 关联模型的 Go 类型名
 }
 
 // Relations 关联关系集合
@@ -95,21 +142,52 @@ type ManyToManyRelation struct {
 
 // FieldInfo 字段信息
 type FieldInfo struct {
-	Name       string // 字段名
-	Type       string // 字段类型
-	Comment    string // 字段注释
-	Tag        string // 结构体标签
-	IsNullable bool   // 是否可为空
-	IsPrimary  bool   // 是否是主键
-	ColumnType string // 数据库列类型
+	Name         string    `json:"name"`              // 字段名
+	Type         string    `json:"type"`              // 字段类型
+	Comment      string    `json:"comment,omitempty"` // 字段注释
+	Tag          string    `json:"tag,omitempty"`     // 结构体标签
+	IsNullable   bool      `json:"is_nullable"`       // 是否可为空
+	IsPrimary    bool      `json:"is_primary"`        // 是否是主键
+	ColumnType   string    `json:"column_type"`       // 数据库列类型
+	GoName       string    `json:"-"`                 // 字段的 Go 名称，生成代码时计算
+	GormOptions  string    `json:"-"`                 // 额外的 gorm 标签选项，如 softDelete:flag
+	ValueType    string    `json:"-"`                 // 非空值的类型，如可为空的列 *string、sql.NullString 对应 string
+	Imports      []string  `json:"-"`                 // 字段类型需要导入的包路径，生成代码时根据类型映射计算
+	ValueImports []string  `json:"-"`                 // 非空值的类型需要导入的包路径
+	Enum         *EnumInfo `json:"-"`                 // ENUM/SET 列生成的枚举类型，生成代码时计算
+	JSON         bool      `json:"-"`                 // 是否为绑定了 Go 类型的 JSON 列
+	Numeric      bool      `json:"-"`                 // 非空值的类型是否为整数或浮点数，生成代码时计算，决定是否生成大于、小于等范围查询
+}
+
+// EnumInfo ENUM/SET 列生成的枚举类型
+type EnumInfo struct {
+	Type     string      // 枚举类型名，如 OrderStatus
+	SetType  string      // SET 列的切片类型名，ENUM 列为空
+	Comment  string      // 字段注释
+	Nullable bool        // 列是否可为空
+	Values   []EnumValue // 可选值
+}
+
+// EnumValue 枚举值
+type EnumValue struct {
+	Name  string // 常量名，如 OrderStatusPaid
+	Value string // 数据库中的值
 }
 
 // IndexInfo 索引信息
 type IndexInfo struct {
-	Name   string   // 索引名
-	Fields []string // 索引字段
-	IsPK   bool     // 是否是主键
-	IsUniq bool     // 是否是唯一索引
+	Name   string   `json:"name"`    // 索引名
+	Fields []string `json:"fields"`  // 索引字段
+	IsPK   bool     `json:"is_pk"`   // 是否是主键
+	IsUniq bool     `json:"is_uniq"` // 是否是唯一索引
+}
+
+// ForeignKeyInfo 外键约束信息
+type ForeignKeyInfo struct {
+	Name      string   `json:"name"`       // 约束名
+	Fields    []string `json:"fields"`     // 本表字段
+	RefTable  string   `json:"ref_table"`  // 引用表
+	RefFields []string `json:"ref_fields"` // 引用表字段
 }
 
 // GenerateOptions 代码生成的配置选项
@@ -119,6 +197,6 @@ type GenerateOptions struct {
 	Tables    []string              // 要生成的表名列表
 	Prefix    string                // 表名前缀
 	Template  string                // 自定义模板路径
-	Style     string                // 文件命名风格: snake(下划线), camel(小驼峰), pascal(大驼峰)
+	Style     string                // 文件命名风格: snake(下划线), camel(小驼峰), pascal(大驼峰), kebab(连字符), lower(全小写)
 	Relations map[string][]Relation // 关联关系配置
 }
@@ -24,11 +24,16 @@ import (
 // 命令行参数
 type cmdFlags struct {
 	DSN      string
+	Driver   string
+	DDL      string
+	Snapshot string
 	Dir      string
 	Tables   string
 	Prefix   string
+	Suffix   string
 	Template string
 	Style    string
+	Nullable string
 }
 
 var (
@@ -38,6 +43,12 @@ var (
 	flags = &cmdFlags{}
 	// 配置文件路径
 	configFile string
+	// 快照输出路径
+	snapshotOutput string
+	// 只检查生成代码是否过期，不写入文件
+	checkOnly bool
+	// 只输出写入计划，不写入文件
+	dryRun bool
 )
 
 // rootCmd represents the base command
@@ -54,149 +65,254 @@ var rootCmd = &cobra.Command{
 
 // genCmd 生成代码的命令
 var genCmd = &cobra.Command{
-	Use:   "gen",
-	Short: "生成代码",
-	Long:  `根据数据库表结构生成 Go 代码，支持自定义模板和多种命名风格。`,
-	PreRunE: func(cmd *cobra.Command, args []string) error {
-		// 如果指定了配置文件，则从配置文件读取
-		if cmd.Flags().Changed("config") {
-			viper.SetConfigFile(configFile)
-			viper.SetConfigType("yaml")
-
-			if err := viper.ReadInConfig(); err != nil {
-				return fmt.Errorf("读取配置文件失败: %v", err)
-			}
-
-			// 从配置文件读取配置
-			flags.DSN = viper.GetString("dsn")
-			flags.Dir = viper.GetString("output.orm_dir")
-			flags.Tables = viper.GetString("tables")
-			flags.Prefix = viper.GetString("prefix")
-			flags.Template = viper.GetString("template")
-			flags.Style = viper.GetString("style")
-			cfg.ModuleName = viper.GetString("module_name")
-			
-			// 读取输出目录配置
-			cfg.Output.OrmDir = viper.GetString("output.orm_dir")
-			cfg.Output.ModelDir = viper.GetString("output.model_dir")
-			cfg.Output.QueryDir = viper.GetString("output.query_dir")
-
-			// 读取关联关系配置
-			if relations := viper.GetStringMap("relations"); len(relations) > 0 {
-				// fmt.Println("\n读取到关联关系配置:")
-				cfg.Relations = make(map[string][]config.Relation)
-				for tableName, rel := range relations {
-					// fmt.Printf("  处理表 %s 的关联关系\n", tableName)
-					if relSlice, ok := rel.([]interface{}); ok {
-						var tableRelations []config.Relation
-						for _, item := range relSlice {
-							if itemMap, ok := item.(map[string]interface{}); ok {
-								relation := config.Relation{
-									Target:         getString(itemMap, "target"),
-									Type:           getString(itemMap, "type"),
-									ForeignKey:     getString(itemMap, "foreign_key"),
-									References:     getString(itemMap, "references"),
-									JoinTable:      getString(itemMap, "join_table"),
-									JoinForeignKey: getString(itemMap, "join_foreign_key"),
-									JoinReferences: getString(itemMap, "join_references"),
-									Comment:        getString(itemMap, "comment"),
-								}
-								// fmt.Printf("    - 目标表: %s, 类型: %s, 外键: %s\n",
-								// 	relation.Target, relation.Type, relation.ForeignKey)
-								tableRelations = append(tableRelations, relation)
-							}
-						}
-						if len(tableRelations) > 0 {
-							cfg.Relations[tableName] = tableRelations
-							// fmt.Printf("  成功添加 %d 个关联关系\n", len(tableRelations))
-						}
-					} else {
-						fmt.Printf("  警告: 表 %s 的关联关系格式不正确\n", tableName)
-					}
-				}
-			} // else {
-			// fmt.Println("\n未找到关联关系配置")
-			// }
+	Use:     "gen",
+	Short:   "生成代码",
+	Long:    `根据数据库表结构生成 Go 代码，支持自定义模板和多种命名风格。`,
+	PreRunE: loadConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 参数已经校验通过，之后的错误不再打印用法
+		cmd.SilenceUsage = true
+		switch {
+		case checkOnly:
+			return cInit.Check(cfg)
+		case dryRun:
+			return cInit.DryRun(cfg)
 		}
 
-		// 命令行参数优先级高于配置文件
-		cmd.Flags().Visit(func(f *pflag.Flag) {
-			switch f.Name {
-			case "dsn":
-				flags.DSN = f.Value.String()
-			case "dir":
-				flags.Dir = f.Value.String()
-			case "tables":
-				flags.Tables = f.Value.String()
-			case "prefix":
-				flags.Prefix = f.Value.String()
-			case "template":
-				flags.Template = f.Value.String()
-			case "style":
-				flags.Style = f.Value.String()
-			}
-		})
+		fmt.Println("执行生成逻辑")
+		// 执行生成逻辑
+		return cInit.Init(cfg)
+	},
+}
 
-		// 验证并转换参数
-		if flags.DSN == "" {
-			return fmt.Errorf("数据库DSN是必填的")
-		}
+// schemaCmd 表结构相关命令
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "表结构快照",
+	Long:  `导出数据库表结构快照，快照可以提交到代码仓库，并通过 gen --from-snapshot 在无数据库的环境中生成代码。`,
+}
 
-		switch flags.Style {
-		case "snake", "camel", "pascal":
-		default:
-			return fmt.Errorf("不支持的命名风格: %s", flags.Style)
-		}
+// schemaDumpCmd 导出表结构快照的命令
+var schemaDumpCmd = &cobra.Command{
+	Use:     "dump",
+	Short:   "导出表结构快照",
+	Long:    `读取表结构（包含推断和配置的关联关系）并导出为 JSON 快照文件。`,
+	PreRunE: loadConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cInit.DumpSchema(cfg, snapshotOutput)
+	},
+}
 
-		// 转换为最终配置
-		cfg.DSN = flags.DSN
-		cfg.Output.OrmDir = flags.Dir
-		if cfg.Output.ModelDir == "" {
-			// 如果没有指定 model_dir，则使用 orm_dir/model 作为 model 目录
-			cfg.Output.ModelDir = filepath.Join(cfg.Output.OrmDir, "model")
+// loadConfig 读取配置文件和命令行参数，生成最终配置
+func loadConfig(cmd *cobra.Command, args []string) error {
+	// 先记录命令行显式指定的参数，参数与 flags 绑定，读取配置文件时会被覆盖
+	changed := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		changed[f.Name] = f.Value.String()
+	})
+
+	// 如果指定了配置文件，则从配置文件读取
+	if cmd.Flags().Changed("config") {
+		viper.SetConfigFile(configFile)
+		viper.SetConfigType("yaml")
+
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("读取配置文件失败: %v", err)
 		}
-		if cfg.Output.QueryDir == "" {
-			// 如果没有指定 query_dir，则使用 orm_dir/query 作为 query 目录
-			cfg.Output.QueryDir = filepath.Join(cfg.Output.OrmDir, "query")
+
+		// 从配置文件读取配置
+		flags.DSN = viper.GetString("dsn")
+		flags.Driver = viper.GetString("driver")
+		flags.DDL = viper.GetString("ddl")
+		flags.Dir = viper.GetString("output.orm_dir")
+		flags.Tables = viper.GetString("tables")
+		// prefix、suffix 可以是列表，也可以是逗号分隔的字符串
+		flags.Prefix = strings.Join(viper.GetStringSlice("prefix"), ",")
+		flags.Suffix = strings.Join(viper.GetStringSlice("suffix"), ",")
+		flags.Template = viper.GetString("template")
+		flags.Style = viper.GetString("style")
+		flags.Nullable = viper.GetString("nullable")
+		cfg.ModuleName = viper.GetString("module_name")
+
+		// 读取输出目录配置
+		cfg.Output.OrmDir = viper.GetString("output.orm_dir")
+		cfg.Output.ModelDir = viper.GetString("output.model_dir")
+		cfg.Output.QueryDir = viper.GetString("output.query_dir")
+
+		// 读取类型映射配置，列名形如 orders.amount，嵌套写法 orders: {amount: ...} 同样支持
+		cfg.Types = flattenStringMap("", viper.Get("types"))
+		cfg.Columns = flattenStringMap("", viper.Get("columns"))
+		cfg.JSON = flattenStringMap("", viper.Get("json"))
+
+		// 读取软删除列配置，未配置时使用默认的 deleted_at、is_deleted
+		if viper.IsSet("soft_delete.columns") {
+			cfg.SoftDelete.Columns = viper.GetStringSlice("soft_delete.columns")
 		}
-		if flags.Tables != "" {
-			cfg.Tables = strings.Split(flags.Tables, ",")
-		} else {
-			cfg.Tables = []string{} // 空切片表示生成所有表
+		if viper.IsSet("soft_delete.flags") {
+			cfg.SoftDelete.Flags = viper.GetStringSlice("soft_delete.flags")
 		}
-		cfg.Prefix = flags.Prefix
-		cfg.Template = flags.Template
-		cfg.Style = flags.Style
 
-		// 如果没有关联关系配置，初始化一个空的 map
-		if cfg.Relations == nil {
+		// 读取乐观锁版本号列配置，可以是列表，也可以是单个列名
+		cfg.Version = viper.GetStringSlice("version")
+
+		// 读取游标分页排序列配置，键为表名
+		cfg.Cursor = viper.GetStringMapStringSlice("cursor")
+
+		// 读取命名配置：词典、表和列的重命名
+		cfg.Naming.Dictionary = flattenStringMap("", viper.Get("naming.dictionary"))
+		cfg.Naming.Tables = flattenStringMap("", viper.Get("naming.tables"))
+		cfg.Naming.Columns = flattenStringMap("", viper.Get("naming.columns"))
+
+		// 读取关联关系配置
+		if relations := viper.GetStringMap("relations"); len(relations) > 0 {
+			// fmt.Println("\n读取到关联关系配置:")
 			cfg.Relations = make(map[string][]config.Relation)
+			for tableName, rel := range relations {
+				// relations.infer 是推断方式配置，不是表名
+				if tableName == "infer" {
+					cfg.RelationInfer = viper.GetString("relations.infer")
+					continue
+				}
+				// fmt.Printf("  处理表 %s 的关联关系\n", tableName)
+				if relSlice, ok := rel.([]interface{}); ok {
+					var tableRelations []config.Relation
+					for _, item := range relSlice {
+						if itemMap, ok := item.(map[string]interface{}); ok {
+							relation := config.Relation{
+								Target:         getString(itemMap, "target"),
+								Type:           getString(itemMap, "type"),
+								ForeignKey:     getString(itemMap, "foreign_key"),
+								References:     getString(itemMap, "references"),
+								JoinTable:      getString(itemMap, "join_table"),
+								JoinForeignKey: getString(itemMap, "join_foreign_key"),
+								JoinReferences: getString(itemMap, "join_references"),
+								Comment:        getString(itemMap, "comment"),
+							}
+							// fmt.Printf("    - 目标表: %s, 类型: %s, 外键: %s\n",
+							// 	relation.Target, relation.Type, relation.ForeignKey)
+							tableRelations = append(tableRelations, relation)
+						}
+					}
+					if len(tableRelations) > 0 {
+						cfg.Relations[tableName] = tableRelations
+						// fmt.Printf("  成功添加 %d 个关联关系\n", len(tableRelations))
+					}
+				} else {
+					fmt.Printf("  警告: 表 %s 的关联关系格式不正确\n", tableName)
+				}
+			}
+		} // else {
+		// fmt.Println("\n未找到关联关系配置")
+		// }
+	}
+
+	// 命令行参数优先级高于配置文件
+	for name, value := range changed {
+		switch name {
+		case "dsn":
+			flags.DSN = value
+		case "driver":
+			flags.Driver = value
+		case "ddl":
+			flags.DDL = value
+		case "dir":
+			flags.Dir = value
+		case "tables":
+			flags.Tables = value
+		case "prefix":
+			flags.Prefix = value
+		case "suffix":
+			flags.Suffix = value
+		case "template":
+			flags.Template = value
+		case "style":
+			flags.Style = value
+		case "nullable":
+			flags.Nullable = value
 		}
+	}
 
-		// 注释掉调试信息输出
-		// fmt.Println("\n关联关系配置:")
-		// for table, relations := range cfg.Relations {
-		// 	fmt.Printf("  表 %s 的关联关系: %d 个\n", table, len(relations))
-		// 	for _, rel := range relations {
-		// 		fmt.Printf("    - [%s] %s -> %s\n", rel.Type, table, rel.Target)
-		// 		fmt.Printf("      外键: %s, 引用: %s\n", rel.ForeignKey, rel.References)
-		// 		if rel.JoinTable != "" {
-		// 			fmt.Printf("      连接表: %s (外键: %s, 引用: %s)\n",
-		// 				rel.JoinTable, rel.JoinForeignKey, rel.JoinReferences)
-		// 		}
-		// 		if rel.Comment != "" {
-		// 			fmt.Printf("      说明: %s\n", rel.Comment)
-		// 		}
-		// 	}
-		// }
+	// 验证并转换参数
+	if flags.DSN == "" && flags.DDL == "" && flags.Snapshot == "" {
+		return fmt.Errorf("数据库DSN是必填的（或通过 --ddl 指定 DDL 文件、--from-snapshot 指定快照文件）")
+	}
 
-		return nil
-	},
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("执行生成逻辑")
-		// 执行生成逻辑
-		return cInit.Init(cfg)
-	},
+	switch cfg.RelationInfer {
+	case "", "fk", "naming", "none":
+	default:
+		return fmt.Errorf("不支持的关联关系推断方式: %s", cfg.RelationInfer)
+	}
+
+	// 从快照生成时，未指定驱动则使用快照中记录的驱动
+	if flags.Driver == "" && flags.Snapshot == "" {
+		flags.Driver = "mysql"
+	}
+	if flags.Driver != "" && !cInit.HasProvider(flags.Driver) {
+		return fmt.Errorf("不支持的数据库驱动: %s（可选: %s）", flags.Driver, strings.Join(cInit.Providers(), ", "))
+	}
+
+	switch flags.Style {
+	case "snake", "camel", "pascal", "kebab", "lower":
+	default:
+		return fmt.Errorf("不支持的命名风格: %s（可选: snake, camel, pascal, kebab, lower）", flags.Style)
+	}
+
+	switch flags.Nullable {
+	case "":
+		flags.Nullable = "pointer"
+	case "pointer", "sql_null", "generic":
+	default:
+		return fmt.Errorf("不支持的可为空列类型: %s（可选: pointer, sql_null, generic）", flags.Nullable)
+	}
+
+	// 转换为最终配置
+	cfg.DSN = flags.DSN
+	cfg.Driver = flags.Driver
+	cfg.DDL = flags.DDL
+	cfg.Snapshot = flags.Snapshot
+	cfg.Output.OrmDir = flags.Dir
+	if cfg.Output.ModelDir == "" {
+		// 如果没有指定 model_dir，则使用 orm_dir/model 作为 model 目录
+		cfg.Output.ModelDir = filepath.Join(cfg.Output.OrmDir, "model")
+	}
+	if cfg.Output.QueryDir == "" {
+		// 如果没有指定 query_dir，则使用 orm_dir/query 作为 query 目录
+		cfg.Output.QueryDir = filepath.Join(cfg.Output.OrmDir, "query")
+	}
+	if flags.Tables != "" {
+		cfg.Tables = strings.Split(flags.Tables, ",")
+	} else {
+		cfg.Tables = []string{} // 空切片表示生成所有表
+	}
+	cfg.Prefix = splitList(flags.Prefix)
+	cfg.Suffix = splitList(flags.Suffix)
+	cfg.Template = flags.Template
+	cfg.Style = flags.Style
+	cfg.Nullable = flags.Nullable
+
+	// 如果没有关联关系配置，初始化一个空的 map
+	if cfg.Relations == nil {
+		cfg.Relations = make(map[string][]config.Relation)
+	}
+
+	// 注释掉调试信息输出
+	// fmt.Println("\n关联关系配置:")
+	// for table, relations := range cfg.Relations {
+	// 	fmt.Printf("  表 %s 的关联关系: %d 个\n", table, len(relations))
+	// 	for _, rel := range relations {
+	// 		fmt.Printf("    - [%s] %s -> %s\n", rel.Type, table, rel.Target)
+	// 		fmt.Printf("      外键: %s, 引用: %s\n", rel.ForeignKey, rel.References)
+	// 		if rel.JoinTable != "" {
+	// 			fmt.Printf("      连接表: %s (外键: %s, 引用: %s)\n",
+	// 				rel.JoinTable, rel.JoinForeignKey, rel.JoinReferences)
+	// 		}
+	// 		if rel.Comment != "" {
+	// 			fmt.Printf("      说明: %s\n", rel.Comment)
+	// 		}
+	// 	}
+	// }
+
+	return nil
 }
 
 // getString 安全地获取 map 中的字符串值
@@ -209,6 +325,39 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+// flattenStringMap 将嵌套的 map 展开为以点号连接键的字符串 map
+func flattenStringMap(prefix string, value interface{}) map[string]string {
+	result := make(map[string]string)
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return result
+	}
+	for key, v := range m {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			for k, s := range flattenStringMap(key, nested) {
+				result[k] = s
+			}
+			continue
+		}
+		result[key] = fmt.Sprint(v)
+	}
+	return result
+}
+
+// splitList 将逗号分隔的字符串拆分为列表，去掉空白和空项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Execute 执行根命令
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -222,12 +371,22 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "配置文件路径")
 
 	// gen 子命令的参数
-	genCmd.Flags().StringVarP(&flags.DSN, "dsn", "d", "", "数据库DSN连接串，格式：user:pass@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local")
+	for _, c := range []*cobra.Command{genCmd, schemaDumpCmd} {
+		c.Flags().StringVarP(&flags.DSN, "dsn", "d", "", "数据库DSN连接串，格式：user:pass@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local，sqlite 为文件路径如 file:schema.db")
+		c.Flags().StringVar(&flags.Driver, "driver", "", "数据库驱动: mysql, postgres, sqlite，默认为 mysql，从快照生成时默认使用快照中记录的驱动")
+		c.Flags().StringVar(&flags.DDL, "ddl", "", "MySQL DDL 文件路径（支持 glob，如 ./migrations/*.sql），指定后无需连接数据库")
+		c.Flags().StringVarP(&flags.Tables, "tables", "t", "", "要生成的表名，多个表用逗号分隔")
+		c.Flags().StringVarP(&flags.Prefix, "prefix", "p", "", "表名前缀，生成代码时会去除这个前缀，多个前缀用逗号分隔")
+		c.Flags().StringVar(&flags.Suffix, "suffix", "", "表名后缀，生成代码时会去除这个后缀，多个后缀用逗号分隔")
+	}
+	genCmd.Flags().StringVar(&flags.Snapshot, "from-snapshot", "", "表结构快照文件路径（由 schema dump 导出），指定后无需连接数据库")
 	genCmd.Flags().StringVarP(&flags.Dir, "dir", "o", ".", "生成代码的输出目录")
-	genCmd.Flags().StringVarP(&flags.Tables, "tables", "t", "", "要生成的表名，多个表用逗号分隔")
-	genCmd.Flags().StringVarP(&flags.Prefix, "prefix", "p", "", "表名前缀，生成代码时会去除这个前缀")
 	genCmd.Flags().StringVar(&flags.Template, "template", "", "自定义模板文件路径")
-	genCmd.Flags().StringVarP(&flags.Style, "style", "s", "snake", "生成的文件命名风格: snake(下划线), camel(小驼峰), pascal(大驼峰)")
+	genCmd.Flags().StringVarP(&flags.Style, "style", "s", "snake", "生成的文件命名风格: snake(下划线), camel(小驼峰), pascal(大驼峰), kebab(连字符), lower(全小写)")
+	genCmd.Flags().StringVar(&flags.Nullable, "nullable", "pointer", "可为空列的类型: pointer(*T), sql_null(sql.NullString 等), generic(sql.Null[T])")
+	genCmd.Flags().BoolVar(&checkOnly, "check", false, "只检查生成代码是否与表结构一致，不写入文件，存在差异时以非零状态退出")
+	genCmd.Flags().BoolVar(&dryRun, "dry-run", false, "只输出将要生成的文件、大小及与现有文件的差异，不写入文件")
+	genCmd.MarkFlagsMutuallyExclusive("check", "dry-run")
 
 	// 设置 viper 默认值
 	viper.SetDefault("dir", ".")
@@ -237,8 +396,12 @@ func init() {
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("ZERO") // 环境变量前缀 ZERO_
 
+	// schema dump 子命令的参数
+	schemaDumpCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "schema.json", "快照文件输出路径")
+
 	// 添加子命令
-	rootCmd.AddCommand(genCmd)
+	schemaCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(genCmd, schemaCmd)
 }
 
 func main() {
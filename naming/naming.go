@@ -0,0 +1,161 @@
+package naming
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+/*
+   @NAME    : naming
+   @author  : 清风
+   @desc    : 表名、列名到 Go 标识符及文件名的转换，支持常见缩写与自定义词典
+   @time    : 2026/10/17
+*/
+
+// commonInitialisms Go 代码中应全部大写的常见缩写，与 golint 保持一致
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "LHS": true, "QPS": true, "RAM": true, "RHS": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true, "XMPP": true,
+	"XSRF": true, "XSS": true,
+}
+
+// Namer 将表名、列名转换为 Go 标识符，每个单词先查自定义词典，再按常见缩写处理
+type Namer struct {
+	dictionary map[string]string
+}
+
+// New 创建 Namer，dictionary 的键为单词（不区分大小写），值为该单词在 Go 标识符中的写法，如 sku: SKU、oauth: OAuth
+func New(dictionary map[string]string) *Namer {
+	words := make(map[string]string, len(dictionary))
+	for word, name := range dictionary {
+		words[strings.ToLower(word)] = name
+	}
+	return &Namer{dictionary: words}
+}
+
+// Pascal 转换为首字母大写的驼峰命名，如 user_id -> UserID、api_url -> APIURL、HTTPStatus -> HTTPStatus
+func (n *Namer) Pascal(s string) string {
+	var sb strings.Builder
+	for _, word := range Words(s) {
+		sb.WriteString(n.word(word))
+	}
+	return sb.String()
+}
+
+// Camel 转换为首字母小写的驼峰命名，第一个单词全部小写，如 user_id -> userID、HTTPLog -> httpLog
+func (n *Namer) Camel(s string) string {
+	words := Words(s)
+	if len(words) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(words[0]))
+	for _, word := range words[1:] {
+		sb.WriteString(n.word(word))
+	}
+	return sb.String()
+}
+
+// word 返回单个单词在 Go 标识符中的写法
+func (n *Namer) word(word string) string {
+	lower := strings.ToLower(word)
+	if name, ok := n.dictionary[lower]; ok {
+		return name
+	}
+	if upper := strings.ToUpper(word); commonInitialisms[upper] {
+		return upper
+	}
+	runes := []rune(lower)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Snake 转换为下划线命名，如 HTTPLog -> http_log、userId -> user_id
+func Snake(s string) string {
+	return joinLower(s, "_")
+}
+
+// Kebab 转换为连字符命名，如 HTTPLog -> http-log、user_id -> user-id
+func Kebab(s string) string {
+	return joinLower(s, "-")
+}
+
+// Lower 转换为全小写且不带分隔符的命名，如 HTTPLog -> httplog、user_id -> userid
+func Lower(s string) string {
+	return joinLower(s, "")
+}
+
+// joinLower 将名称拆分的单词转换为小写后用 sep 连接
+func joinLower(s, sep string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
+
+// Exported 将 Pascal 转换的结果调整为合法的导出标识符：
+// 首字符不是大写字母（如以数字开头、中文名称）时加 X 前缀，为空时返回 X
+func Exported(name string) string {
+	if !token.IsExported(name) {
+		name = "X" + name
+	}
+	return name
+}
+
+// PackageName 将目录名转换为合法的包名：转为小写并去掉字母、数字、下划线以外的字符，
+// 以数字开头时加 p 前缀，是关键字时加 _ 后缀，如 go-model -> gomodel、type -> type_
+func PackageName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(r)
+		}
+	}
+	name = sb.String()
+	switch {
+	case name == "":
+		return "pkg"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "p" + name
+	case token.IsKeyword(name):
+		return name + "_"
+	}
+	return name
+}
+
+// Words 将名称拆分为单词：按字母、数字以外的字符分隔，并在大小写变化处拆分，
+// 如 user_id -> user、id，userId -> user、Id，HTTPStatus -> HTTP、Status。数字归入前一个单词
+func Words(s string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := current[len(current)-1]
+			// 小写或数字后的大写字母开始新单词；连续大写字母中，后面跟小写字母的那个开始新单词
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
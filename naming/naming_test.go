@@ -0,0 +1,127 @@
+package naming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"user_id", []string{"user", "id"}},
+		{"userId", []string{"user", "Id"}},
+		{"userID", []string{"user", "ID"}},
+		{"HTTPStatus", []string{"HTTP", "Status"}},
+		{"HTTPLog", []string{"HTTP", "Log"}},
+		{"oauth2_token", []string{"oauth2", "token"}},
+		{"Order-Item.v2", []string{"Order", "Item", "v2"}},
+		{"  user  name ", []string{"user", "name"}},
+		{"用户名", []string{"用户名"}},
+		{"__", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := Words(tt.s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSnakeKebabLower(t *testing.T) {
+	tests := []struct {
+		s                   string
+		snake, kebab, lower string
+	}{
+		{"HTTPLog", "http_log", "http-log", "httplog"},
+		{"userId", "user_id", "user-id", "userid"},
+		{"UserID", "user_id", "user-id", "userid"},
+		{"user_id", "user_id", "user-id", "userid"},
+		{"APIKey2", "api_key2", "api-key2", "apikey2"},
+		{"Order-Item", "order_item", "order-item", "orderitem"},
+		{"", "", "", ""},
+	}
+	for _, tt := range tests {
+		if got := Snake(tt.s); got != tt.snake {
+			t.Errorf("Snake(%q) = %q, want %q", tt.s, got, tt.snake)
+		}
+		if got := Kebab(tt.s); got != tt.kebab {
+			t.Errorf("Kebab(%q) = %q, want %q", tt.s, got, tt.kebab)
+		}
+		if got := Lower(tt.s); got != tt.lower {
+			t.Errorf("Lower(%q) = %q, want %q", tt.s, got, tt.lower)
+		}
+	}
+}
+
+func TestNamer(t *testing.T) {
+	dictionary := map[string]string{"sku": "SKU", "OAuth": "OAuth", "id": "Id"}
+	tests := []struct {
+		dictionary    map[string]string
+		s             string
+		pascal, camel string
+	}{
+		// 常见缩写
+		{nil, "user_id", "UserID", "userID"},
+		{nil, "userId", "UserID", "userID"},
+		{nil, "api_url", "APIURL", "apiURL"},
+		{nil, "HTTPLog", "HTTPLog", "httpLog"},
+		{nil, "json_data", "JSONData", "jsonData"},
+		{nil, "utf8_name", "UTF8Name", "utf8Name"},
+		{nil, "order_item", "OrderItem", "orderItem"},
+		{nil, "id", "ID", "id"},
+		{nil, "", "", ""},
+		// 词典优先于常见缩写，键不区分大小写，第一个单词在 Camel 中仍为小写
+		{dictionary, "sku_id", "SKUId", "skuId"},
+		{dictionary, "oauth_token", "OAuthToken", "oauthToken"},
+		{dictionary, "product_sku", "ProductSKU", "productSKU"},
+		{dictionary, "api_url", "APIURL", "apiURL"},
+	}
+	for _, tt := range tests {
+		n := New(tt.dictionary)
+		if got := n.Pascal(tt.s); got != tt.pascal {
+			t.Errorf("Pascal(%q) = %q, want %q", tt.s, got, tt.pascal)
+		}
+		if got := n.Camel(tt.s); got != tt.camel {
+			t.Errorf("Camel(%q) = %q, want %q", tt.s, got, tt.camel)
+		}
+	}
+}
+
+func TestExported(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"User", "User"},
+		{"1st", "X1st"},
+		{"用户", "X用户"},
+		{"_tmp", "X_tmp"},
+		{"", "X"},
+	}
+	for _, tt := range tests {
+		if got := Exported(tt.name); got != tt.want {
+			t.Errorf("Exported(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"model", "model"},
+		{"Query", "query"},
+		{"go-model", "gomodel"},
+		{"orm_v2", "orm_v2"},
+		{"2fa", "p2fa"},
+		{"type", "type_"},
+		{"---", "pkg"},
+		{"模型", "模型"},
+	}
+	for _, tt := range tests {
+		if got := PackageName(tt.name); got != tt.want {
+			t.Errorf("PackageName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+   @NAME    : diff
+   @author  : 清风
+   @desc    : 按行比较文本并输出统一格式（unified）的差异
+   @time    : 2026/10/17
+*/
+
+// diffContext 差异块前后保留的上下文行数
+const diffContext = 3
+
+// lineEdit 一行的编辑操作，op 为 ' '（相同）、'-'（删除）或 '+'（新增）
+type lineEdit struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff 比较两段文本，返回统一格式的差异，内容相同时返回空字符串
+func UnifiedDiff(oldName, newName string, oldText, newText []byte) string {
+	a, b := splitLines(string(oldText)), splitLines(string(newText))
+	edits := diffLines(a, b)
+
+	// oldPos/newPos[i] 为第 i 个编辑操作之前已经过的旧/新文件行数
+	oldPos := make([]int, len(edits)+1)
+	newPos := make([]int, len(edits)+1)
+	for i, edit := range edits {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if edit.op != '+' {
+			oldPos[i+1]++
+		}
+		if edit.op != '-' {
+			newPos[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// 向后合并间隔不超过两倍上下文的变更
+		end := i
+		for j := i; j < len(edits) && j-end <= 2*diffContext+1; j++ {
+			if edits[j].op != ' ' {
+				end = j
+			}
+		}
+		start := max(i-diffContext, 0)
+		stop := min(end+1+diffContext, len(edits))
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(oldPos[start], oldPos[stop]-oldPos[start]),
+			hunkRange(newPos[start], newPos[stop]-newPos[start]))
+		for _, edit := range edits[start:stop] {
+			sb.WriteByte(edit.op)
+			sb.WriteString(edit.text)
+			if !strings.HasSuffix(edit.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return sb.String()
+}
+
+// hunkRange 差异块的行范围，行数为 0 时起始行为前一行
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines 按行拆分文本，每行保留换行符
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines 使用 Myers 算法计算将 a 变为 b 的最短编辑序列
+func diffLines(a, b []string) []lineEdit {
+	// 公共前缀和后缀不参与计算
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []lineEdit
+	for _, line := range a[:prefix] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	return edits
+}
+
+// myers Myers 差异算法，trace[d] 记录第 d 轮开始前各对角线 k ∈ [-d-1, d+1] 上到达的最远 x
+func myers(a, b []string) []lineEdit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		done := false
+		for k := -d; k <= d && !done; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			done = x >= n && y >= m
+		}
+		if done {
+			break
+		}
+	}
+
+	// 从终点回溯得到编辑序列
+	var edits []lineEdit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, lineEdit{' ', a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, lineEdit{'+', b[prevY]})
+			} else {
+				edits = append(edits, lineEdit{'-', a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/*
+   @NAME    : types
+   @author  : 清风
+   @desc    : 数据库类型模式匹配与 Go 类型（含导入路径）解析
+   @time    : 2026/10/17
+*/
+
+// knownPackages 常用包名对应的导入路径，用于不带路径的类型，如 json.RawMessage
+var knownPackages = map[string]string{
+	"time":      "time",
+	"json":      "encoding/json",
+	"sql":       "database/sql",
+	"driver":    "database/sql/driver",
+	"big":       "math/big",
+	"netip":     "net/netip",
+	"pq":        "github.com/lib/pq",
+	"decimal":   "github.com/shopspring/decimal",
+	"datatypes": "gorm.io/datatypes",
+}
+
+var (
+	// identPattern Go 标识符
+	identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// majorVersion 模块路径中的主版本号，如 /v2
+	majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+	// typeArgs 类型参数，如 (10,2)
+	typeArgs = regexp.MustCompile(`\s*\([^)]*\)`)
+)
+
+// ParseGoType 解析类型配置，返回代码中使用的类型和需要导入的包路径。
+// 支持内置类型（bool、[]byte）、带包名的类型（json.RawMessage）以及
+// 带完整导入路径的类型（github.com/shopspring/decimal.Decimal），可带 * 或 [] 前缀
+func ParseGoType(spec string) (goType, importPath string, err error) {
+	spec = strings.TrimSpace(spec)
+	rest := spec
+	var modifier string
+	for {
+		if strings.HasPrefix(rest, "*") {
+			modifier += "*"
+			rest = rest[1:]
+		} else if strings.HasPrefix(rest, "[]") {
+			modifier += "[]"
+			rest = rest[2:]
+		} else {
+			break
+		}
+	}
+
+	dot := strings.LastIndex(rest, ".")
+	if dot < 0 {
+		if !identPattern.MatchString(rest) {
+			return "", "", fmt.Errorf("无效的类型: %s", spec)
+		}
+		return spec, "", nil
+	}
+
+	path, name := rest[:dot], rest[dot+1:]
+	if !identPattern.MatchString(name) || path == "" {
+		return "", "", fmt.Errorf("无效的类型: %s", spec)
+	}
+	if !strings.Contains(path, "/") {
+		if known, ok := knownPackages[path]; ok {
+			path = known
+		}
+	}
+	return modifier + PackageName(path) + "." + name, path, nil
+}
+
+// PackageName 根据导入路径推断包名，忽略主版本号（/v2、.v3）及 go- 前缀
+func PackageName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && majorVersion.MatchString(name) {
+		name = parts[len(parts)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 && majorVersion.MatchString(name[i+1:]) {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.NewReplacer("-", "", ".", "").Replace(name)
+}
+
+// TypeImport 返回内置映射生成的类型（如 *time.Time、pq.StringArray）需要导入的包路径
+func TypeImport(goType string) string {
+	name := strings.TrimLeft(goType, "*[]")
+	dot := strings.Index(name, ".")
+	if dot < 0 {
+		return ""
+	}
+	return knownPackages[name[:dot]]
+}
+
+// sqlNullTypes database/sql 中与 Go 类型对应的可为空类型
+var sqlNullTypes = map[string]string{
+	"string":    "sql.NullString",
+	"int":       "sql.NullInt64",
+	"int64":     "sql.NullInt64",
+	"int32":     "sql.NullInt32",
+	"int16":     "sql.NullInt16",
+	"uint8":     "sql.NullByte",
+	"byte":      "sql.NullByte",
+	"float64":   "sql.NullFloat64",
+	"bool":      "sql.NullBool",
+	"time.Time": "sql.NullTime",
+}
+
+// NullableType 可为空列的 Go 类型，strategy 为可为空列的表示方式：
+//   - pointer（默认）: *T
+//   - sql_null: sql.NullString、sql.NullInt64 等，没有对应类型时使用 sql.Null[T]
+//   - generic: sql.Null[T]（Go 1.22+）
+//
+// 指针和切片类型（如 []byte、json.RawMessage、pq.StringArray）本身可以用 nil 表示 NULL，保持不变
+func NullableType(goType, strategy string) string {
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") ||
+		goType == "json.RawMessage" || (strings.HasPrefix(goType, "pq.") && strings.HasSuffix(goType, "Array")) {
+		return goType
+	}
+
+	switch strategy {
+	case "sql_null":
+		if nullType, ok := sqlNullTypes[goType]; ok {
+			return nullType
+		}
+		return "sql.Null[" + goType + "]"
+	case "generic":
+		return "sql.Null[" + goType + "]"
+	default:
+		return "*" + goType
+	}
+}
+
+// NormalizeColumnType 规范化列类型：小写、合并空白、去掉括号和逗号两侧的空白
+func NormalizeColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(strings.ToLower(columnType)), " ")
+	return strings.NewReplacer(" (", "(", "( ", "(", " )", ")", ", ", ",", " ,", ",").Replace(columnType)
+}
+
+// MatchColumnType 判断列类型是否匹配类型模式：
+//   - 带括号的模式需要参数一致，* 匹配任意参数，如 decimal(*,*)、tinyint(1)
+//   - 不带括号的模式忽略列类型的参数，如 bigint unsigned 匹配 bigint(20) unsigned
+func MatchColumnType(pattern, columnType string) bool {
+	pattern = NormalizeColumnType(pattern)
+	columnType = NormalizeColumnType(columnType)
+
+	if !strings.Contains(pattern, "(") {
+		return pattern == NormalizeColumnType(typeArgs.ReplaceAllString(columnType, " "))
+	}
+
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `[^,()]*`)
+	matched, _ := regexp.MatchString("^"+expr+"$", columnType)
+	return matched
+}
+
+// PatternSpecificity 类型模式的优先级，数值越大越优先：
+// 带确定参数的模式优先于带通配符的模式，带括号的模式优先于不带括号的模式
+func PatternSpecificity(pattern string) int {
+	pattern = NormalizeColumnType(pattern)
+	switch {
+	case !strings.Contains(pattern, "("):
+		return 0
+	case strings.Contains(pattern, "*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ParseEnumValues 解析 MySQL ENUM/SET 列类型中的可选值，如 enum('a','b')，值中的单引号写作两个单引号，
+// kind 为 enum 或 set，列类型不是 ENUM/SET 时 ok 为 false
+func ParseEnumValues(columnType string) (kind string, values []string, ok bool) {
+	columnType = strings.TrimSpace(columnType)
+	open := strings.Index(columnType, "(")
+	if open < 0 {
+		return "", nil, false
+	}
+	kind = strings.ToLower(strings.TrimSpace(columnType[:open]))
+	if kind != "enum" && kind != "set" {
+		return "", nil, false
+	}
+
+	rest := columnType[open+1:]
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			return "", nil, false
+		}
+		if rest[0] == ')' {
+			return kind, values, true
+		}
+		if rest[0] == ',' {
+			rest = rest[1:]
+			continue
+		}
+		if rest[0] != '\'' {
+			return "", nil, false
+		}
+
+		// 单引号字符串，'' 和 \ 为转义
+		var sb strings.Builder
+		i := 1
+		for ; i < len(rest); i++ {
+			c := rest[i]
+			if c == '\\' && i+1 < len(rest) {
+				i++
+				sb.WriteByte(rest[i])
+				continue
+			}
+			if c == '\'' {
+				if i+1 < len(rest) && rest[i+1] == '\'' {
+					sb.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			sb.WriteByte(c)
+		}
+		if i >= len(rest) {
+			return "", nil, false
+		}
+		values = append(values, sb.String())
+		rest = rest[i+1:]
+	}
+}
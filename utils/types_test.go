@@ -0,0 +1,143 @@
+package utils
+
+import "testing"
+
+func TestParseGoType(t *testing.T) {
+	tests := []struct {
+		spec       string
+		goType     string
+		importPath string
+		wantErr    bool
+	}{
+		// 内置类型
+		{spec: "int64", goType: "int64"},
+		{spec: " bool ", goType: "bool"},
+		{spec: "[]byte", goType: "[]byte"},
+		{spec: "*string", goType: "*string"},
+		// 常用包名
+		{spec: "json.RawMessage", goType: "json.RawMessage", importPath: "encoding/json"},
+		{spec: "*time.Time", goType: "*time.Time", importPath: "time"},
+		{spec: "pq.StringArray", goType: "pq.StringArray", importPath: "github.com/lib/pq"},
+		{spec: "decimal.Decimal", goType: "decimal.Decimal", importPath: "github.com/shopspring/decimal"},
+		// 完整导入路径
+		{spec: "github.com/shopspring/decimal.Decimal", goType: "decimal.Decimal", importPath: "github.com/shopspring/decimal"},
+		{spec: "*github.com/shopspring/decimal.Decimal", goType: "*decimal.Decimal", importPath: "github.com/shopspring/decimal"},
+		{spec: "[]github.com/google/uuid.UUID", goType: "[]uuid.UUID", importPath: "github.com/google/uuid"},
+		{spec: "github.com/jackc/pgx/v5/pgtype.Numeric", goType: "pgtype.Numeric", importPath: "github.com/jackc/pgx/v5/pgtype"},
+		{spec: "github.com/foo/bar/v2.Point", goType: "bar.Point", importPath: "github.com/foo/bar/v2"},
+		{spec: "gopkg.in/guregu/null.v4.String", goType: "null.String", importPath: "gopkg.in/guregu/null.v4"},
+		{spec: "github.com/foo/go-geo.Point", goType: "geo.Point", importPath: "github.com/foo/go-geo"},
+		{spec: "example.com/app/pkg/types.Money", goType: "types.Money", importPath: "example.com/app/pkg/types"},
+		// 未知的包名作为导入路径
+		{spec: "geo.Point", goType: "geo.Point", importPath: "geo"},
+		// 无效的类型
+		{spec: "", wantErr: true},
+		{spec: "map[string]int", wantErr: true},
+		{spec: "decimal.", wantErr: true},
+		{spec: ".Decimal", wantErr: true},
+		{spec: "github.com/foo/bar.1x", wantErr: true},
+	}
+	for _, tt := range tests {
+		goType, importPath, err := ParseGoType(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseGoType(%q) = %q, %q，期望返回错误", tt.spec, goType, importPath)
+			}
+			continue
+		}
+		if err != nil || goType != tt.goType || importPath != tt.importPath {
+			t.Errorf("ParseGoType(%q) = %q, %q, %v，期望 %q, %q", tt.spec, goType, importPath, err, tt.goType, tt.importPath)
+		}
+	}
+}
+
+func TestMatchColumnType(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		columnType string
+		want       bool
+	}{
+		// 通配符参数
+		{"decimal(*,*)", "decimal(10,2)", true},
+		{"decimal(*,*)", "DECIMAL(10, 2)", true},
+		{"decimal(*,*)", "decimal(10,2) unsigned", false},
+		{"decimal(*)", "decimal(10)", true},
+		{"decimal(*)", "decimal(10,2)", false},
+		{"decimal(10,*)", "decimal(10,4)", true},
+		{"decimal(10,*)", "decimal(12,4)", false},
+		// 确定的参数
+		{"tinyint(1)", "tinyint(1)", true},
+		{"tinyint(1)", "TINYINT( 1 )", true},
+		{"tinyint(1)", "tinyint(4)", false},
+		{"tinyint(1)", "tinyint(1) unsigned", false},
+		// 不带括号的模式忽略参数
+		{"tinyint", "tinyint(4)", true},
+		{"tinyint", "tinyint(1)", true},
+		{"bigint unsigned", "bigint(20) unsigned", true},
+		{"bigint unsigned", "BIGINT  UNSIGNED", true},
+		{"bigint unsigned", "bigint(20)", false},
+		{"bigint", "bigint(20) unsigned", false},
+		{"int", "bigint", false},
+		{"varchar", "varchar(255)", true},
+		{"enum", "enum('a','b')", true},
+	}
+	for _, tt := range tests {
+		if got := MatchColumnType(tt.pattern, tt.columnType); got != tt.want {
+			t.Errorf("MatchColumnType(%q, %q) = %v，期望 %v", tt.pattern, tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestPatternSpecificity(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    int
+	}{
+		{"decimal", 0},
+		{"bigint unsigned", 0},
+		{"decimal(*,*)", 1},
+		{"decimal(10,*)", 1},
+		{"tinyint(1)", 2},
+		{"decimal(10,2)", 2},
+	}
+	for _, tt := range tests {
+		if got := PatternSpecificity(tt.pattern); got != tt.want {
+			t.Errorf("PatternSpecificity(%q) = %d，期望 %d", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestNullableType(t *testing.T) {
+	tests := []struct {
+		goType   string
+		strategy string
+		want     string
+	}{
+		{"string", "", "*string"},
+		{"string", "pointer", "*string"},
+		{"time.Time", "pointer", "*time.Time"},
+		{"string", "sql_null", "sql.NullString"},
+		{"int", "sql_null", "sql.NullInt64"},
+		{"int32", "sql_null", "sql.NullInt32"},
+		{"uint8", "sql_null", "sql.NullByte"},
+		{"float64", "sql_null", "sql.NullFloat64"},
+		{"bool", "sql_null", "sql.NullBool"},
+		{"time.Time", "sql_null", "sql.NullTime"},
+		// 没有对应的 sql.NullXxx 时使用 sql.Null[T]
+		{"uint64", "sql_null", "sql.Null[uint64]"},
+		{"float32", "sql_null", "sql.Null[float32]"},
+		{"decimal.Decimal", "sql_null", "sql.Null[decimal.Decimal]"},
+		{"string", "generic", "sql.Null[string]"},
+		{"time.Time", "generic", "sql.Null[time.Time]"},
+		// 本身可以表示 NULL 的类型保持不变
+		{"*string", "generic", "*string"},
+		{"[]byte", "sql_null", "[]byte"},
+		{"json.RawMessage", "pointer", "json.RawMessage"},
+		{"pq.StringArray", "generic", "pq.StringArray"},
+	}
+	for _, tt := range tests {
+		if got := NullableType(tt.goType, tt.strategy); got != tt.want {
+			t.Errorf("NullableType(%q, %q) = %q，期望 %q", tt.goType, tt.strategy, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package utils
 import (
 	"fmt"
 	"strings"
+	"unicode"
+
+	"github.com/tokmz/zero/naming"
 )
 
 /*
@@ -12,16 +15,9 @@ import (
    @time    : 2025/2/6 11:33
 */
 
-// ToSnake 转换为蛇形命名
+// ToSnake 转换为蛇形命名，连续的大写字母视为一个单词，如 HTTPLog -> http_log
 func ToSnake(s string) string {
-	var result []rune
-	for i, r := range s {
-		if i > 0 && (r >= 'A' && r <= 'Z') {
-			result = append(result, '_')
-		}
-		result = append(result, rune(strings.ToLower(string(r))[0]))
-	}
-	return string(result)
+	return naming.Snake(s)
 }
 
 // ToCamel 转换为驼峰命名
@@ -48,38 +44,186 @@ func ToCamel(s string) string {
 	return string(result)
 }
 
-// GetGoType 将数据库类型转换为 Go 类型
-func GetGoType(dbType string) string {
-	switch strings.ToLower(dbType) {
-	case "tinyint", "smallint", "mediumint", "int", "integer":
+// GetGoType 将 MySQL 列类型（COLUMN_TYPE，如 int(10) unsigned、tinyint(1)）转换为 Go 类型
+//   - 有符号的 tinyint、smallint、mediumint、int 为 int，bigint 为 int64
+//   - 无符号整数按宽度对应 uint8、uint16、uint32、uint64
+//   - tinyint(1) 为 bool，year 为 int
+//   - binary、varbinary、blob、bit(n) 及空间类型为 []byte；bit(1) 同样为 []byte，
+//     MySQL 驱动将 BIT 返回为原始字节（如 "\x01"），database/sql 无法将其转换为 bool
+//   - time 可能超出 24 小时，驱动也不会解析为 time.Time，因此为 string
+//
+// 只传入 DATA_TYPE（不带参数和 unsigned）时同样适用
+func GetGoType(columnType string) string {
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	unsigned := strings.Contains(columnType, "unsigned")
+
+	baseType, args := columnType, ""
+	if i := strings.IndexAny(baseType, "( "); i >= 0 {
+		baseType = columnType[:i]
+		if columnType[i] == '(' {
+			if j := strings.Index(columnType[i:], ")"); j >= 0 {
+				args = columnType[i+1 : i+j]
+			}
+		}
+	}
+
+	switch baseType {
+	case "tinyint":
+		if args == "1" {
+			return "bool"
+		}
+		if unsigned {
+			return "uint8"
+		}
+		return "int"
+	case "smallint":
+		if unsigned {
+			return "uint16"
+		}
+		return "int"
+	case "mediumint", "int", "integer":
+		if unsigned {
+			return "uint32"
+		}
 		return "int"
 	case "bigint":
+		if unsigned {
+			return "uint64"
+		}
 		return "int64"
-	case "float", "double", "decimal", "numeric":
+	case "bool", "boolean":
+		return "bool"
+	case "year":
+		return "int"
+	case "float", "double", "real", "decimal", "numeric":
 		return "float64"
-	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set", "time":
 		return "string"
-	case "date", "datetime", "timestamp", "time":
+	case "date", "datetime", "timestamp":
 		return "time.Time"
-	case "tinyint(1)", "bool", "boolean":
-		return "bool"
 	case "json":
 		return "json.RawMessage"
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bit",
+		"geometry", "point", "linestring", "polygon", "multipoint", "multilinestring", "multipolygon", "geometrycollection":
+		return "[]byte"
 	default:
 		return "string"
 	}
 }
 
-// BuildFieldTags 构建字段标签
-func BuildFieldTags(name, columnType string, isNullable bool) string {
+// GetPostgresGoType 将 PostgreSQL 类型（pg_type.typname）转换为 Go 类型
+func GetPostgresGoType(udtName string) string {
+	udtName = strings.ToLower(udtName)
+	// 数组类型的 typname 以下划线开头，如 _int4、_text
+	if strings.HasPrefix(udtName, "_") {
+		switch strings.TrimPrefix(udtName, "_") {
+		case "int2", "int4", "int8":
+			return "pq.Int64Array"
+		case "float4", "float8", "numeric":
+			return "pq.Float64Array"
+		case "bool":
+			return "pq.BoolArray"
+		case "bytea":
+			return "pq.ByteaArray"
+		default:
+			return "pq.StringArray"
+		}
+	}
+
+	switch udtName {
+	case "int2", "int4":
+		return "int"
+	case "int8":
+		return "int64"
+	case "float4", "float8", "numeric", "money":
+		return "float64"
+	case "bool":
+		return "bool"
+	case "varchar", "bpchar", "char", "text", "citext", "name", "uuid", "inet", "cidr", "macaddr", "interval":
+		return "string"
+	case "date", "timestamp", "timestamptz", "time", "timetz":
+		return "time.Time"
+	case "json", "jsonb":
+		return "json.RawMessage"
+	case "bytea":
+		return "[]byte"
+	default:
+		return "string"
+	}
+}
+
+// GetSQLiteGoType 将 SQLite 声明类型转换为 Go 类型
+// 先识别常见的具体类型名，其余按 SQLite 的类型亲和性规则处理
+func GetSQLiteGoType(declType string) string {
+	declType = strings.ToLower(strings.TrimSpace(declType))
+	baseType := declType
+	if i := strings.Index(baseType, "("); i >= 0 {
+		baseType = strings.TrimSpace(baseType[:i])
+	}
+
+	switch baseType {
+	case "bool", "boolean":
+		return "bool"
+	case "date", "datetime", "timestamp", "time":
+		return "time.Time"
+	case "json":
+		return "json.RawMessage"
+	}
+
+	switch {
+	case strings.Contains(baseType, "int"):
+		return "int64"
+	case strings.Contains(baseType, "char"), strings.Contains(baseType, "clob"), strings.Contains(baseType, "text"):
+		return "string"
+	case baseType == "", strings.Contains(baseType, "blob"):
+		return "[]byte"
+	case strings.Contains(baseType, "real"), strings.Contains(baseType, "floa"), strings.Contains(baseType, "doub"):
+		return "float64"
+	default:
+		// NUMERIC 亲和性，如 numeric、decimal
+		return "float64"
+	}
+}
+
+// BuildFieldTags 构建字段标签，options 为额外的 gorm 标签选项，如 softDelete:flag。
+// 列名和列类型中的引号、反斜杠会被转义，反射读取标签时得到原始的值
+func BuildFieldTags(name, columnType string, isNullable bool, options ...string) string {
 	// 移除多余的空格
 	columnType = strings.TrimSpace(columnType)
 
 	// 构建 gorm tag
-	gormTag := fmt.Sprintf("column:%s;type:%s", name, columnType)
+	gormTag := fmt.Sprintf("column:%s;type:%s", EscapeTag(name), EscapeTag(columnType))
 	if !isNullable {
 		gormTag += ";not null"
 	}
+	for _, option := range options {
+		if option != "" {
+			gormTag += ";" + option
+		}
+	}
+
+	return fmt.Sprintf(`gorm:"%s" json:"%s"`, gormTag, JSONTagName(name))
+}
 
-	return fmt.Sprintf(`gorm:"%s" json:"%s"`, gormTag, name)
+// EscapeTag 转义结构体标签值中的反斜杠和双引号
+func EscapeTag(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// JSONTagName 将列名转换为合法的 json 标签名：encoding/json 不支持的字符（引号、反斜杠、逗号等）替换为下划线，
+// 列名为 - 时写作 -, 以免字段被忽略
+func JSONTagName(name string) string {
+	if name == "-" {
+		return "-,"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+			return r
+		default:
+			return '_'
+		}
+	}, name)
 }
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       string
+	}{
+		// 有符号整数
+		{"tinyint(4)", "int"},
+		{"smallint(6)", "int"},
+		{"mediumint(9)", "int"},
+		{"int(11)", "int"},
+		{"integer", "int"},
+		{"bigint(20)", "int64"},
+		// 无符号整数
+		{"tinyint(3) unsigned", "uint8"},
+		{"smallint(5) unsigned", "uint16"},
+		{"mediumint(8) unsigned", "uint32"},
+		{"int(10) unsigned", "uint32"},
+		{"int unsigned zerofill", "uint32"},
+		{"bigint(20) unsigned", "uint64"},
+		{"BIGINT UNSIGNED", "uint64"},
+		// 布尔
+		{"tinyint(1)", "bool"},
+		{"tinyint(1) unsigned", "bool"},
+		{"bool", "bool"},
+		{"boolean", "bool"},
+		// BIT：驱动返回原始字节，bit(1) 也不映射为 bool
+		{"bit(1)", "[]byte"},
+		{"bit(8)", "[]byte"},
+		{"bit(64)", "[]byte"},
+		// 浮点数与定点数
+		{"float", "float64"},
+		{"float(7,4)", "float64"},
+		{"double", "float64"},
+		{"real", "float64"},
+		{"decimal(10,2)", "float64"},
+		{"numeric(20,6) unsigned", "float64"},
+		// 字符串
+		{"char(36)", "string"},
+		{"varchar(255)", "string"},
+		{"tinytext", "string"},
+		{"text", "string"},
+		{"mediumtext", "string"},
+		{"longtext", "string"},
+		{"enum('a','b')", "string"},
+		{"set('x','y')", "string"},
+		// 日期与时间
+		{"year", "int"},
+		{"year(4)", "int"},
+		{"date", "time.Time"},
+		{"datetime", "time.Time"},
+		{"datetime(3)", "time.Time"},
+		{"timestamp", "time.Time"},
+		{"time", "string"},
+		{"time(6)", "string"},
+		// JSON
+		{"json", "json.RawMessage"},
+		// 二进制
+		{"binary(16)", "[]byte"},
+		{"varbinary(255)", "[]byte"},
+		{"tinyblob", "[]byte"},
+		{"blob", "[]byte"},
+		{"mediumblob", "[]byte"},
+		{"longblob", "[]byte"},
+		// 空间类型
+		{"geometry", "[]byte"},
+		{"point", "[]byte"},
+		{"linestring", "[]byte"},
+		{"polygon", "[]byte"},
+		{"multipoint", "[]byte"},
+		{"multilinestring", "[]byte"},
+		{"multipolygon", "[]byte"},
+		{"geometrycollection", "[]byte"},
+		// 只有 DATA_TYPE（不带参数和 unsigned）
+		{"tinyint", "int"},
+		{"smallint", "int"},
+		{"int", "int"},
+		{"bigint", "int64"},
+		{"bit", "[]byte"},
+		{"decimal", "float64"},
+		{"varchar", "string"},
+		{"  DATETIME  ", "time.Time"},
+		// 未知类型
+		{"unknown_type", "string"},
+	}
+	for _, tt := range tests {
+		if got := GetGoType(tt.columnType); got != tt.want {
+			t.Errorf("GetGoType(%q) = %q, want %q", tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestGetPostgresGoType(t *testing.T) {
+	tests := []struct {
+		udtName string
+		want    string
+	}{
+		// 整数
+		{"int2", "int"},
+		{"int4", "int"},
+		{"int8", "int64"},
+		// 浮点数与定点数
+		{"float4", "float64"},
+		{"float8", "float64"},
+		{"numeric", "float64"},
+		{"money", "float64"},
+		{"bool", "bool"},
+		// 字符串
+		{"varchar", "string"},
+		{"bpchar", "string"},
+		{"text", "string"},
+		{"citext", "string"},
+		{"uuid", "string"},
+		{"inet", "string"},
+		{"interval", "string"},
+		// 时间
+		{"date", "time.Time"},
+		{"timestamp", "time.Time"},
+		{"timestamptz", "time.Time"},
+		{"timetz", "time.Time"},
+		// JSON 与二进制
+		{"json", "json.RawMessage"},
+		{"jsonb", "json.RawMessage"},
+		{"bytea", "[]byte"},
+		// 数组
+		{"_int2", "pq.Int64Array"},
+		{"_int4", "pq.Int64Array"},
+		{"_int8", "pq.Int64Array"},
+		{"_float8", "pq.Float64Array"},
+		{"_numeric", "pq.Float64Array"},
+		{"_bool", "pq.BoolArray"},
+		{"_bytea", "pq.ByteaArray"},
+		{"_text", "pq.StringArray"},
+		{"_varchar", "pq.StringArray"},
+		{"_uuid", "pq.StringArray"},
+		{"_jsonb", "pq.StringArray"},
+		// 大小写与未知类型
+		{"INT8", "int64"},
+		{"_INT4", "pq.Int64Array"},
+		{"tsvector", "string"},
+	}
+	for _, tt := range tests {
+		if got := GetPostgresGoType(tt.udtName); got != tt.want {
+			t.Errorf("GetPostgresGoType(%q) = %q, want %q", tt.udtName, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFieldTagsEscapesQuotes(t *testing.T) {
+	tests := []struct {
+		name, columnType string
+		wantGorm         string
+	}{
+		{"id", "bigint", "column:id;type:bigint;not null"},
+		{`na"me`, "varchar(32)", `column:na"me;type:varchar(32);not null`},
+		{`pa\th`, "varchar(32)", `column:pa\th;type:varchar(32);not null`},
+		{"kind", `enum('a"b','c')`, `column:kind;type:enum('a"b','c');not null`},
+	}
+	for _, tt := range tests {
+		tag := reflect.StructTag(BuildFieldTags(tt.name, tt.columnType, false))
+		if got := tag.Get("gorm"); got != tt.wantGorm {
+			t.Errorf("BuildFieldTags(%q, %q) 的 gorm 标签为 %q，期望 %q", tt.name, tt.columnType, got, tt.wantGorm)
+		}
+		if got := tag.Get("json"); got != JSONTagName(tt.name) {
+			t.Errorf("BuildFieldTags(%q, %q) 的 json 标签为 %q，期望 %q", tt.name, tt.columnType, got, JSONTagName(tt.name))
+		}
+	}
+}